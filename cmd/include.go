@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/museslabs/kyma/internal/markdown"
+	"github.com/museslabs/kyma/internal/paths"
+)
+
+var includeRe = regexp.MustCompile(`^ {0,3}!include\s+(\S.*?)\s*$`)
+
+// source is the origin of a line of the expanded deck.
+type source struct {
+	file string
+	line int
+}
+
+// expandIncludes replaces every `!include <path>` line in data, read from
+// file, with the contents of the included file. Includes nest, paths are
+// relative to the including file and cycles are rejected. Along with the
+// expanded deck it returns the source of every line and the included files.
+func expandIncludes(data, file string) (string, []source, []string, error) {
+	var (
+		b    strings.Builder
+		srcs []source
+		deps []string
+	)
+	err := expand(&b, &srcs, &deps, data, file, nil)
+	return b.String(), srcs, deps, err
+}
+
+func expand(b *strings.Builder, srcs *[]source, deps *[]string, data, file string, stack []string) error {
+	stack = append(stack, file)
+
+	lines := strings.SplitAfter(data, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var fences markdown.Fences
+	for i, line := range lines {
+		kind := fences.Next(line)
+		match := includeRe.FindStringSubmatch(strings.TrimRight(line, "\r\n"))
+		if kind != markdown.Text || match == nil {
+			b.WriteString(line)
+			*srcs = append(*srcs, source{file: file, line: i + 1})
+			continue
+		}
+
+		included := paths.Resolve(filepath.Dir(file), match[1])
+		fail := func(err error) error {
+			return fmt.Errorf("%s:%d: include %s: %w", displayPath(file), i+1, match[1], err)
+		}
+
+		for j, f := range stack {
+			if f == included {
+				cycle := make([]string, 0, len(stack)-j+1)
+				for _, f := range append(stack[j:], included) {
+					cycle = append(cycle, displayPath(f))
+				}
+				return fail(fmt.Errorf("include cycle %s", strings.Join(cycle, " -> ")))
+			}
+		}
+
+		*deps = append(*deps, included)
+		content, err := os.ReadFile(included)
+		if err != nil {
+			return fail(err)
+		}
+
+		// Resolve assets against the included file, the deck is resolved
+		// against its own directory later on.
+		resolved, assets, err := resolveAssets(string(content), filepath.Dir(included))
+		*deps = append(*deps, assets...)
+		if err != nil {
+			return fail(err)
+		}
+		if resolved != "" && !strings.HasSuffix(resolved, "\n") {
+			resolved += "\n"
+		}
+
+		if err := expand(b, srcs, deps, resolved, included, stack); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// displayPath returns path relative to the working directory when it is
+// inside of it, for shorter error messages.
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return rel
+}
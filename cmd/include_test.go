@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestExpandIncludes(t *testing.T) {
+	tt := []struct {
+		name     string
+		files    map[string]string
+		expected string
+		sources  []string
+		// err is the expected error, or its beginning.
+		err string
+	}{
+		{
+			name: "nested",
+			files: map[string]string{
+				"deck.md":      "# Deck\n!include parts/a.md\nend\n",
+				"parts/a.md":   "a\n  !include b/b.md\n",
+				"parts/b/b.md": "b\n",
+			},
+			expected: "# Deck\na\nb\nend\n",
+			sources:  []string{"deck.md:1", "parts/a.md:1", "parts/b/b.md:1", "deck.md:3"},
+		},
+		{
+			name: "fenced",
+			files: map[string]string{
+				"deck.md": "```\n!include a.md\n```\n",
+			},
+			expected: "```\n!include a.md\n```\n",
+			sources:  []string{"deck.md:1", "deck.md:2", "deck.md:3"},
+		},
+		{
+			name: "direct cycle",
+			files: map[string]string{
+				"deck.md": "!include a.md\n",
+				"a.md":    "x\n!include a.md\n",
+			},
+			err: "a.md:2: include a.md: include cycle a.md -> a.md",
+		},
+		{
+			name: "indirect cycle",
+			files: map[string]string{
+				"deck.md": "!include a.md\n",
+				"a.md":    "!include b.md\n",
+				"b.md":    "\n\n!include a.md\n",
+			},
+			err: "b.md:3: include a.md: include cycle a.md -> b.md -> a.md",
+		},
+		{
+			name: "missing file",
+			files: map[string]string{
+				"deck.md": "# Deck\n\n!include missing.md\n",
+			},
+			err: "deck.md:3: include missing.md: open ",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				path := filepath.Join(dir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// Paths in errors are relative to the working directory.
+			t.Chdir(dir)
+
+			deck := filepath.Join(dir, "deck.md")
+			actual, srcs, _, err := expandIncludes(tc.files["deck.md"], deck)
+			if tc.err != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), filepath.FromSlash(tc.err)) {
+					t.Fatalf("expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+
+			var sources []string
+			for _, src := range srcs {
+				rel, _ := filepath.Rel(dir, src.file)
+				sources = append(sources, filepath.ToSlash(rel)+":"+strconv.Itoa(src.line))
+			}
+			if !reflect.DeepEqual(sources, tc.sources) {
+				t.Errorf("expected sources %q, got %q", tc.sources, sources)
+			}
+		})
+	}
+}
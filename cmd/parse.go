@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/museslabs/kyma/internal/markdown"
+	"github.com/museslabs/kyma/internal/paths"
+	"github.com/museslabs/kyma/internal/tui"
+)
+
+var imageRe = regexp.MustCompile(`(!\[[^\]]*\]\()([^)\s]+)`)
+
+// loadDeck reads and parses the deck in filename, which is either a markdown
+// file or a directory of them. Along with the slides it returns the absolute
+// paths of every file the deck depends on, so that they can be watched for
+// changes.
+func loadDeck(filename string) (*tui.Slide, []string, error) {
+	absPath, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return nil, []string{absPath}, err
+	}
+
+	files := []string{absPath}
+	if info.IsDir() {
+		files, err = deckFiles(absPath)
+		if err != nil {
+			return nil, []string{absPath}, err
+		}
+	}
+
+	var (
+		b    strings.Builder
+		srcs []source
+		deps = []string{absPath}
+	)
+	for i, file := range files {
+		if i > 0 {
+			b.WriteString("----\n")
+			srcs = append(srcs, source{file: file})
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, deps, err
+		}
+
+		expanded, fileSrcs, includes, err := expandIncludes(string(data), file)
+		deps = append(deps, includes...)
+		if err != nil {
+			return nil, deps, err
+		}
+		if expanded != "" && !strings.HasSuffix(expanded, "\n") {
+			expanded += "\n"
+		}
+
+		b.WriteString(expanded)
+		srcs = append(srcs, fileSrcs...)
+	}
+
+	root, slideDeps, err := parseSlides(b.String(), srcs)
+	return root, append(deps, slideDeps...), err
+}
+
+// loadStdin reads and parses a deck from r. Relative paths in the deck are
+// resolved against the working directory.
+func loadStdin(r io.Reader) (*tui.Slide, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	expanded, srcs, _, err := expandIncludes(string(data), filepath.Join(wd, "<stdin>"))
+	if err != nil {
+		return nil, err
+	}
+
+	root, _, err := parseSlides(expanded, srcs)
+	return root, err
+}
+
+// deckFiles returns the markdown files making up the deck in dir, in lexical
+// order so that numbered files like 01-intro.md come out in sequence.
+func deckFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if isDeckFile(entry.Name()) && !entry.IsDir() {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no markdown files found in %s", dir)
+	}
+	return files, nil
+}
+
+func isDeckFile(name string) bool {
+	return filepath.Ext(name) == ".md" && !strings.HasPrefix(name, ".")
+}
+
+// parseSlides parses the expanded deck in data, srcs holds the origin of each
+// of its lines and is used to resolve relative paths against the file each
+// slide was written in.
+func parseSlides(data string, srcs []source) (*tui.Slide, []string, error) {
+	slides := strings.Split(string(data), "----\n")
+
+	var (
+		root *tui.Slide
+		curr *tui.Slide
+		deps []string
+		line int
+	)
+	for _, slide := range slides {
+		baseDir := ""
+		if line < len(srcs) {
+			baseDir = filepath.Dir(srcs[line].file)
+		}
+		line += strings.Count(slide, "\n") + 1
+
+		slide, properties := parseSlide(slide)
+		p, err := tui.NewProperties(properties, tui.ParseOptions{Strict: strict, BaseDir: baseDir})
+		if err != nil {
+			return nil, deps, err
+		}
+		deps = append(deps, p.Style.ThemeFiles...)
+
+		slide, assets, err := resolveAssets(slide, baseDir)
+		deps = append(deps, assets...)
+		if err != nil {
+			return nil, deps, err
+		}
+
+		next := &tui.Slide{
+			Data:       slide,
+			Prev:       curr,
+			Properties: p,
+		}
+		if curr == nil {
+			root = next
+		} else {
+			curr.Next = next
+		}
+		curr = next
+	}
+
+	return root, deps, nil
+}
+
+func parseSlide(s string) (slide, properties string) {
+	slide = s
+
+	if strings.HasPrefix(strings.TrimSpace(s), "---\n") {
+		parts := strings.Split(s, "---\n")
+		properties = parts[1]
+		slide = parts[2]
+	}
+
+	return slide, properties
+}
+
+// resolveAssets fills code blocks importing a file with its code and returns
+// the local files the slide depends on, including the images it shows,
+// resolved against baseDir. Image paths are left as written so that local
+// paths aren't shown to the audience, and images inside code are ignored.
+func resolveAssets(slide, baseDir string) (string, []string, error) {
+	var (
+		out      []string
+		fences   markdown.Fences
+		imported bool
+		assets   []string
+	)
+	for _, line := range strings.Split(slide, "\n") {
+		switch fences.Next(line) {
+		case markdown.Open:
+			s, ok := parseSnippet(fences.Info())
+			if !ok {
+				out = append(out, line)
+				continue
+			}
+
+			code, path, err := s.load(baseDir)
+			assets = append(assets, path)
+			if err != nil {
+				return slide, assets, err
+			}
+
+			marker, _, _ := markdown.Fence(line)
+			indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+			f := fenceFor(marker, code)
+			out = append(out, indent+f+strings.Join(s.info, " "))
+			for _, l := range code {
+				out = append(out, indent+l)
+			}
+			out = append(out, indent+f)
+			imported = true
+		case markdown.Code:
+			// The body of an importing code block is replaced by the code.
+			if !imported {
+				out = append(out, line)
+			}
+		case markdown.Close:
+			// Already closed along with the imported code.
+			if !imported {
+				out = append(out, line)
+			}
+			imported = false
+		default:
+			markdown.OutsideCodeSpans(line, func(text string) string {
+				for _, m := range imageRe.FindAllStringSubmatch(text, -1) {
+					if path, local := resolveAsset(baseDir, m[2]); local {
+						assets = append(assets, filepath.FromSlash(path))
+					}
+				}
+				return text
+			})
+			out = append(out, line)
+		}
+	}
+
+	return strings.Join(out, "\n"), assets, nil
+}
+
+func resolveAsset(baseDir, path string) (string, bool) {
+	if u, err := url.Parse(path); err == nil && u.Scheme != "" && len(u.Scheme) > 1 {
+		// Remote asset, the length check keeps windows drive letters local.
+		return path, false
+	}
+	if strings.HasPrefix(path, "#") {
+		return path, false
+	}
+	return filepath.ToSlash(paths.Resolve(baseDir, path)), true
+}
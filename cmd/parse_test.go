@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveAssets(t *testing.T) {
+	base := filepath.FromSlash("/deck")
+
+	tt := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"local image", "![logo](./logo.png)", []string{filepath.FromSlash("/deck/logo.png")}},
+		{"remote image", "![logo](https://example.com/logo.png)", nil},
+		{"anchor", "![logo](#logo)", nil},
+		{"code span", "see `![logo](./logo.png)` and ![a](a.png)", []string{filepath.FromSlash("/deck/a.png")}},
+		{"double backtick code span", "``![logo](./logo.png)``", nil},
+		{"fenced code", "```md\n![logo](./logo.png)\n```", nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			slide, assets, err := resolveAssets(tc.input, base)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if slide != tc.input {
+				t.Errorf("expected the slide to be unchanged, got %q", slide)
+			}
+			if !reflect.DeepEqual(assets, tc.expected) {
+				t.Errorf("expected assets %q, got %q", tc.expected, assets)
+			}
+		})
+	}
+}
+
+func TestLoadDeckDirectory(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"10-end.md":      "---\ntransition: swipeLeft\n---\n# End\n",
+		"01-intro.md":    "# Intro\n----\n# Intro 2\n",
+		"02-middle.md":   "---\nid: middle\n---\n# Middle",
+		"notes.txt":      "# Notes\n",
+		".draft.md":      "# Draft\n",
+		"extra.md/a.md":  "# Extra\n",
+		"images/logo.md": "# Logo\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	root, deps, err := loadDeck(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deps) == 0 || deps[0] != dir {
+		t.Errorf("expected the directory to be the first dependency, got %q", deps)
+	}
+
+	var slides []string
+	for s := root; s != nil; s = s.Next {
+		slides = append(slides, s.Data)
+	}
+	expected := []string{"# Intro\n", "# Intro 2\n", "# Middle\n", "# End\n"}
+	if !reflect.DeepEqual(slides, expected) {
+		t.Fatalf("expected slides %q, got %q", expected, slides)
+	}
+
+	// Front matter at the start of a file applies to its first slide.
+	middle, end := root.Next.Next, root.Next.Next.Next
+	if middle.Properties.ID != "middle" {
+		t.Errorf("expected the id %q, got %q", "middle", middle.Properties.ID)
+	}
+	if name := end.Properties.Transition.Name(); name != "swipeLeft" {
+		t.Errorf("expected the transition %q, got %q", "swipeLeft", name)
+	}
+}
+
+func TestLoadDeckEmptyDirectory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, deps, err := loadDeck(dir)
+	if expected := "no markdown files found in " + dir; err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+	if !reflect.DeepEqual(deps, []string{dir}) {
+		t.Errorf("expected the directory to be watched for slides, got %q", deps)
+	}
+}
+
+func TestLoadStdin(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "part.md"), []byte("# Included\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Includes and assets are resolved against the working directory.
+	t.Chdir(dir)
+
+	root, err := loadStdin(strings.NewReader("---\nid: first\n---\n# Piped\n----\n!include part.md\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var slides []string
+	for s := root; s != nil; s = s.Next {
+		slides = append(slides, s.Data)
+	}
+	expected := []string{"# Piped\n", "# Included\n"}
+	if !reflect.DeepEqual(slides, expected) {
+		t.Errorf("expected slides %q, got %q", expected, slides)
+	}
+	if root.Properties.ID != "first" {
+		t.Errorf("expected the id %q, got %q", "first", root.Properties.ID)
+	}
+
+	if _, err := loadStdin(strings.NewReader("!include missing.md\n")); err == nil {
+		t.Error("expected an error for a missing include")
+	}
+}
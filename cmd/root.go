@@ -4,122 +4,107 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
-	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/cobra"
 
 	"github.com/museslabs/kyma/internal/tui"
-	"github.com/museslabs/kyma/internal/tui/transitions"
 )
 
-var watch bool
+var (
+	watch       bool
+	strict      bool
+	followEdits bool
+)
 
 func init() {
 	rootCmd.Flags().BoolVarP(&watch, "watch", "w", false, "Watch for changes in the input file")
+	rootCmd.Flags().BoolVar(&followEdits, "follow-edits", false, "Jump to the slide that changed when reloading, requires --watch")
+	rootCmd.Flags().BoolVar(&strict, "strict", false, "Reject unknown front matter keys and exit on failed reloads")
 	rootCmd.AddCommand(versionCmd)
 }
 
 var rootCmd = &cobra.Command{
-	Use: "kyma <filename>",
+	Use: "kyma <filename | directory | ->",
 	Args: func(cmd *cobra.Command, args []string) error {
 		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
 			return err
 		}
 
+		if followEdits && !watch {
+			return fmt.Errorf("--follow-edits requires --watch")
+		}
+
+		if args[0] == "-" {
+			if watch {
+				return fmt.Errorf("can't watch for changes when reading from stdin")
+			}
+			return nil
+		}
+
+		if info, err := os.Stat(args[0]); err == nil && info.IsDir() {
+			return nil
+		}
+
 		if filepath.Ext(args[0]) != ".md" {
-			return fmt.Errorf("expected markdown file got: %v", args[0])
+			return fmt.Errorf("expected markdown file or directory got: %v", args[0])
 		}
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		filename := args[0]
 
-		data, err := os.ReadFile(filename)
+		programOpts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseAllMotion()}
+
+		var (
+			root *tui.Slide
+			deps []string
+			err  error
+		)
+		if filename == "-" {
+			root, err = loadStdin(os.Stdin)
+			// Stdin holds the deck, read keys from the terminal instead.
+			programOpts = append(programOpts, tea.WithInputTTY())
+		} else {
+			root, deps, err = loadDeck(filename)
+		}
 		if err != nil {
 			return err
 		}
 
-		root, err := parseSlides(string(data))
-		if err != nil {
-			return err
+		var opts []tui.Option
+		if followEdits {
+			opts = append(opts, tui.WithFollowEdits())
 		}
 
-		p := tea.NewProgram(tui.New(root), tea.WithAltScreen(), tea.WithMouseAllMotion())
+		p := tea.NewProgram(tui.New(root, opts...), programOpts...)
+
+		// In strict mode a failed reload stops the presentation, the error is
+		// handed back here to be returned once the program exits.
+		reloadErr := make(chan error, 1)
 
 		if watch {
 			watcher, err := fsnotify.NewWatcher()
 			if err != nil {
-				p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
-				return nil
+				return err
 			}
 			defer watcher.Close()
 
-			absPath, err := filepath.Abs(filename)
-			if err != nil {
-				p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
-				return nil
-			}
-
-			if err := watcher.Add(filepath.Dir(absPath)); err != nil {
-				p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
-			}
-
-			go watchFileChanges(watcher, p, filename, absPath)
+			go watchFileChanges(watcher, p, filename, deps, reloadErr)
 		}
 
 		if _, err := p.Run(); err != nil {
 			return err
 		}
 
-		return nil
-	},
-}
-
-func watchFileChanges(watcher *fsnotify.Watcher, p *tea.Program, filename, absPath string) {
-	var debounceTimer *time.Timer
-
-	for {
 		select {
-		case event, ok := <-watcher.Events:
-			if !ok {
-				return
-			}
-
-			if event.Name == absPath || event.Name == filename ||
-				strings.HasSuffix(event.Name, "~") ||
-				strings.HasPrefix(event.Name, absPath+".") {
-
-				if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) {
-					if debounceTimer != nil {
-						debounceTimer.Stop()
-					}
-					debounceTimer = time.AfterFunc(100*time.Millisecond, func() {
-						data, err := os.ReadFile(filename)
-						if err != nil {
-							p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
-							return
-						}
-
-						newRoot, err := parseSlides(string(data))
-						if err != nil {
-							p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
-							return
-						}
-
-						p.Send(tui.UpdateSlidesMsg{NewRoot: newRoot})
-					})
-				}
-			}
-		case err, ok := <-watcher.Errors:
-			if !ok {
-				return
-			}
-			p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
+		case err := <-reloadErr:
+			return err
+		default:
+			return nil
 		}
-	}
+	},
 }
 
 func Execute() {
@@ -128,60 +113,3 @@ func Execute() {
 		os.Exit(1)
 	}
 }
-
-func parseSlides(data string) (*tui.Slide, error) {
-	slides := strings.Split(string(data), "----\n")
-
-	rootSlide, properties := parseSlide(slides[0])
-	p, err := tui.NewProperties(properties)
-	if err != nil {
-		return nil, err
-	}
-
-	root := &tui.Slide{
-		Data:       rootSlide,
-		Properties: p,
-	}
-
-	curr := root
-	for _, slide := range slides[1:] {
-		slide, properties := parseSlide(slide)
-		p, err := tui.NewProperties(properties)
-		if err != nil {
-			return nil, err
-		}
-
-		curr.Next = &tui.Slide{
-			Data:       slide,
-			Prev:       curr,
-			Properties: p,
-		}
-		curr = curr.Next
-	}
-
-	return root, nil
-}
-
-func parseSlide(s string) (slide, properties string) {
-	slide = s
-
-	if strings.HasPrefix(strings.TrimSpace(s), "---\n") {
-		parts := strings.Split(s, "---\n")
-		properties = parts[1]
-		slide = parts[2]
-	}
-
-	return slide, properties
-}
-
-func createErrorSlide(err error, transition string) *tui.Slide {
-	return &tui.Slide{
-		Data: fmt.Sprintf(
-			"# Error while updating\n\n%s\n\nIf you believe this is our fault, please open up an issue on GitHub",
-			err.Error(),
-		),
-		Properties: tui.Properties{
-			Transition: transitions.Get(transition, tui.Fps),
-		},
-	}
-}
@@ -0,0 +1,41 @@
+package cmd
+
+import "testing"
+
+func TestArgs(t *testing.T) {
+	dir := t.TempDir()
+
+	tt := []struct {
+		name        string
+		args        []string
+		watch       bool
+		followEdits bool
+		// err is the expected error, or empty when the arguments are valid.
+		err string
+	}{
+		{name: "file", args: []string{"deck.md"}},
+		{name: "directory", args: []string{dir}, watch: true},
+		{name: "stdin", args: []string{"-"}},
+		{name: "watching stdin", args: []string{"-"}, watch: true, err: "can't watch for changes when reading from stdin"},
+		{name: "following edits", args: []string{"deck.md"}, watch: true, followEdits: true},
+		{name: "following edits without watching", args: []string{"deck.md"}, followEdits: true, err: "--follow-edits requires --watch"},
+		{name: "not markdown", args: []string{"deck.txt"}, err: "expected markdown file or directory got: deck.txt"},
+		{name: "no file", err: "accepts 1 arg(s), received 0"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			prevWatch, prevFollowEdits := watch, followEdits
+			t.Cleanup(func() { watch, followEdits = prevWatch, prevFollowEdits })
+			watch, followEdits = tc.watch, tc.followEdits
+
+			err := rootCmd.Args(rootCmd, tc.args)
+			if tc.err == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if tc.err != "" && (err == nil || err.Error() != tc.err) {
+				t.Errorf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/museslabs/kyma/internal/paths"
+)
+
+// snippet is a code block importing its contents from a file, declared with
+// attributes in the fence info string:
+//
+//	```go file=./main.go lines=10-30
+//	```go file=./main.go region=handler
+type snippet struct {
+	file   string
+	lines  string
+	region string
+	info   []string
+}
+
+// parseSnippet parses the info string of a code fence and reports whether it
+// imports a file. The remaining info, like the language, is kept in info.
+func parseSnippet(info string) (snippet, bool) {
+	var s snippet
+	for _, field := range strings.Fields(info) {
+		key, value, ok := strings.Cut(field, "=")
+		switch {
+		case ok && key == "file":
+			s.file = value
+		case ok && key == "lines":
+			s.lines = value
+		case ok && key == "region":
+			s.region = value
+		default:
+			s.info = append(s.info, field)
+		}
+	}
+	return s, s.file != ""
+}
+
+// load reads the code of the snippet, relative paths are resolved against
+// baseDir. It returns the code lines and the path of the file read.
+func (s snippet) load(baseDir string) ([]string, string, error) {
+	path := paths.Resolve(baseDir, s.file)
+	fail := func(err error) ([]string, string, error) {
+		return nil, path, fmt.Errorf("code file %s: %w", s.file, err)
+	}
+
+	if s.lines != "" && s.region != "" {
+		return fail(errors.New("lines and region can't be combined"))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fail(err)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	switch {
+	case s.lines != "":
+		lines, err = lineRange(lines, s.lines)
+	case s.region != "":
+		lines, err = region(lines, s.region)
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	return dedent(lines), path, nil
+}
+
+// lineRange returns the 1-based inclusive range of lines in spec, which is
+// either a single line or a range with optional bounds, like 10-30, 10- or
+// -30.
+func lineRange(lines []string, spec string) ([]string, error) {
+	from, to, isRange := strings.Cut(spec, "-")
+	if !isRange {
+		to = from
+	}
+
+	bound := func(s string, def int) (int, error) {
+		if s == "" && isRange {
+			return def, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return 0, fmt.Errorf("invalid lines: %s, expected a range like 10-30", spec)
+		}
+		return n, nil
+	}
+
+	start, err := bound(from, 1)
+	if err != nil {
+		return nil, err
+	}
+	end, err := bound(to, len(lines))
+	if err != nil {
+		return nil, err
+	}
+
+	if start > end || end > len(lines) {
+		return nil, fmt.Errorf("lines %s out of range, the file has %d lines", spec, len(lines))
+	}
+	return lines[start-1 : end], nil
+}
+
+// region returns the lines between the `region <name>` and `endregion`
+// marker comments. Markers of other regions inside it are dropped.
+func region(lines []string, name string) ([]string, error) {
+	start := -1
+	for i, line := range lines {
+		marker, markerName, ok := regionMarker(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case start < 0 && marker == "region" && markerName == name:
+			start = i + 1
+		case start >= 0 && marker == "endregion" && (markerName == "" || markerName == name):
+			var code []string
+			for _, line := range lines[start:i] {
+				if _, _, ok := regionMarker(line); !ok {
+					code = append(code, line)
+				}
+			}
+			return code, nil
+		}
+	}
+
+	if start < 0 {
+		return nil, fmt.Errorf("region %s not found", name)
+	}
+	return nil, fmt.Errorf("region %s is never closed with endregion", name)
+}
+
+var commentPrefixes = []string{"//", "#", "--", ";", "/*", "<!--", "'"}
+
+// regionMarker reports whether line is a comment like `// region name` or
+// `# endregion` and returns the marker and the region name.
+func regionMarker(line string) (marker, name string, ok bool) {
+	line = strings.TrimSpace(line)
+
+	var comment bool
+	for _, prefix := range commentPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			line = strings.TrimPrefix(line, prefix)
+			comment = true
+			break
+		}
+	}
+	if !comment {
+		return "", "", false
+	}
+
+	line = strings.TrimSuffix(strings.TrimSuffix(line, "*/"), "-->")
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", "", false
+	}
+
+	marker = strings.TrimPrefix(fields[0], "#")
+	if marker != "region" && marker != "endregion" {
+		return "", "", false
+	}
+	if len(fields) > 1 {
+		name = fields[1]
+	}
+	return marker, name, true
+}
+
+// dedent removes the leading whitespace shared by all non-blank lines. Tabs
+// and spaces only match themselves, so mixed indentation is kept.
+func dedent(lines []string) []string {
+	indent := ""
+	first := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lead := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first {
+			indent, first = lead, false
+			continue
+		}
+		n := 0
+		for n < len(indent) && n < len(lead) && indent[n] == lead[n] {
+			n++
+		}
+		indent = indent[:n]
+	}
+	if indent == "" {
+		return lines
+	}
+
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			out[i] = line[len(indent):]
+		}
+	}
+	return out
+}
+
+// fenceFor returns a fence made of the same character as fence that is long
+// enough not to be closed by any line of code.
+func fenceFor(fence string, code []string) string {
+	c := fence[:1]
+	for _, line := range code {
+		trimmed := strings.TrimLeft(line, " ")
+		run := len(trimmed) - len(strings.TrimLeft(trimmed, c))
+		if run >= len(fence) {
+			fence = strings.Repeat(c, run+1)
+		}
+	}
+	return fence
+}
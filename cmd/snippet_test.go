@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLineRange(t *testing.T) {
+	lines := []string{"1", "2", "3", "4", "5", "6"}
+
+	tests := []struct {
+		name string
+		give string
+		want string
+		err  string
+	}{
+		{name: "single", give: "3", want: "3"},
+		{name: "range", give: "2-4", want: "2 3 4"},
+		{name: "first line", give: "1-1", want: "1"},
+		{name: "last line", give: "6", want: "6"},
+		{name: "whole file", give: "1-6", want: "1 2 3 4 5 6"},
+		{name: "open end", give: "5-", want: "5 6"},
+		{name: "open start", give: "-3", want: "1 2 3"},
+		{name: "open both", give: "-", want: "1 2 3 4 5 6"},
+		{name: "past the end", give: "5-7", err: "lines 5-7 out of range, the file has 6 lines"},
+		{name: "single past the end", give: "7", err: "lines 7 out of range, the file has 6 lines"},
+		{name: "open end past the end", give: "7-", err: "lines 7- out of range, the file has 6 lines"},
+		{name: "reversed", give: "4-2", err: "lines 4-2 out of range, the file has 6 lines"},
+		{name: "zero", give: "0-2", err: "invalid lines: 0-2, expected a range like 10-30"},
+		{name: "not a number", give: "a-b", err: "invalid lines: a-b, expected a range like 10-30"},
+		{name: "empty", give: "", err: "invalid lines: , expected a range like 10-30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lineRange(lines, tt.give)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("Expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(got, " ") != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, strings.Join(got, " "))
+			}
+		})
+	}
+}
+
+func TestRegion(t *testing.T) {
+	tests := []struct {
+		name   string
+		give   string
+		region string
+		want   string
+		err    string
+	}{
+		{
+			name:   "go",
+			give:   "package main\n// region main\nfunc main() {}\n// endregion\n",
+			region: "main",
+			want:   "func main() {}",
+		},
+		{
+			name:   "named end",
+			give:   "# region a\nx = 1\n# endregion a\ny = 2\n",
+			region: "a",
+			want:   "x = 1",
+		},
+		{
+			name:   "nested markers dropped",
+			give:   "// region outer\na\n// region inner\nb\n// endregion inner\nc\n// endregion outer\n",
+			region: "outer",
+			want:   "a\nb\nc",
+		},
+		{
+			name:   "inner",
+			give:   "// region outer\na\n// region inner\nb\n// endregion\nc\n// endregion\n",
+			region: "inner",
+			want:   "b",
+		},
+		{
+			name:   "block comments",
+			give:   "<!-- region page -->\n<p></p>\n<!-- endregion -->\n/* #region css */\np {}\n/* #endregion */\n",
+			region: "css",
+			want:   "p {}",
+		},
+		{
+			name:   "not a comment",
+			give:   "region a\nx\nendregion\n",
+			region: "a",
+			err:    "region a not found",
+		},
+		{
+			name:   "missing",
+			give:   "// region a\nx\n// endregion\n",
+			region: "b",
+			err:    "region b not found",
+		},
+		{
+			name:   "never closed",
+			give:   "// region a\nx\n",
+			region: "a",
+			err:    "region a is never closed with endregion",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := region(strings.Split(strings.TrimSuffix(tt.give, "\n"), "\n"), tt.region)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("Expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(got, "\n") != tt.want {
+				t.Errorf("Expected:\n\n`%s`\n\nActual Output:\n\n`%s`", tt.want, strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+func TestDedent(t *testing.T) {
+	tests := []struct {
+		name string
+		give []string
+		want []string
+	}{
+		{
+			name: "none",
+			give: []string{"a", "  b"},
+			want: []string{"a", "  b"},
+		},
+		{
+			name: "spaces",
+			give: []string{"    a", "      b", "    c"},
+			want: []string{"a", "  b", "c"},
+		},
+		{
+			name: "tabs",
+			give: []string{"\t\ta", "\t\t\tb"},
+			want: []string{"a", "\tb"},
+		},
+		{
+			name: "blank lines",
+			give: []string{"  a", "", " ", "    b"},
+			want: []string{"a", "", "", "  b"},
+		},
+		{
+			name: "mixed tabs and spaces",
+			give: []string{"\ta", "    b"},
+			want: []string{"\ta", "    b"},
+		},
+		{
+			name: "shared tab",
+			give: []string{"\t  a", "\tb"},
+			want: []string{"  a", "b"},
+		},
+		{
+			name: "empty",
+			give: nil,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dedent(tt.give); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/museslabs/kyma/internal/tui"
+)
+
+// depWatcher tracks the files a deck depends on. Files are watched through
+// their parent directories so that editors replacing files on save, and files
+// that don't exist yet, are picked up. Directory decks are watched for slide
+// files being added, removed and renamed.
+type depWatcher struct {
+	watcher *fsnotify.Watcher
+	deps    map[string]struct{}
+	dirs    map[string]struct{}
+	decks   map[string]struct{}
+}
+
+func newDepWatcher(watcher *fsnotify.Watcher) *depWatcher {
+	return &depWatcher{
+		watcher: watcher,
+		deps:    make(map[string]struct{}),
+		dirs:    make(map[string]struct{}),
+		decks:   make(map[string]struct{}),
+	}
+}
+
+// set replaces the watched dependencies, adding and removing directory
+// watches as needed.
+func (w *depWatcher) set(deps []string) {
+	w.deps = make(map[string]struct{})
+	w.decks = make(map[string]struct{})
+	w.add(deps)
+
+	for dir := range w.dirs {
+		if !w.needsDir(dir) {
+			_ = w.watcher.Remove(dir)
+			delete(w.dirs, dir)
+		}
+	}
+}
+
+// add watches the given dependencies on top of the current ones.
+func (w *depWatcher) add(deps []string) {
+	for _, dep := range deps {
+		dep = filepath.Clean(dep)
+		w.deps[dep] = struct{}{}
+
+		dir := filepath.Dir(dep)
+		if info, err := os.Stat(dep); err == nil && info.IsDir() {
+			w.decks[dep] = struct{}{}
+			dir = dep
+		}
+		if _, ok := w.dirs[dir]; ok {
+			continue
+		}
+		// Directories that don't exist can't be watched, a later reload will
+		// try again.
+		if err := w.watcher.Add(dir); err == nil {
+			w.dirs[dir] = struct{}{}
+		}
+	}
+}
+
+func (w *depWatcher) needsDir(dir string) bool {
+	if _, ok := w.decks[dir]; ok {
+		return true
+	}
+	for dep := range w.deps {
+		if filepath.Dir(dep) == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether the event concerns one of the dependencies,
+// including backup and swap files written by editors.
+func (w *depWatcher) matches(event fsnotify.Event) bool {
+	name := filepath.Clean(event.Name)
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+
+	if _, ok := w.decks[filepath.Dir(name)]; ok && isDeckFile(filepath.Base(name)) {
+		return true
+	}
+
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+		return false
+	}
+	for dep := range w.deps {
+		if name == dep || name == dep+"~" || strings.HasPrefix(name, dep+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func watchFileChanges(
+	watcher *fsnotify.Watcher,
+	p *tea.Program,
+	filename string,
+	deps []string,
+	reloadErr chan<- error,
+) {
+	var debounceTimer *time.Timer
+
+	w := newDepWatcher(watcher)
+	w.set(deps)
+
+	// Reloads are funneled back into this goroutine so the dependency set is
+	// only ever touched from here.
+	reload := make(chan struct{}, 1)
+
+	reportError := func(err error) {
+		if strict {
+			select {
+			case reloadErr <- err:
+			default:
+			}
+			p.Quit()
+			return
+		}
+		p.Send(tui.ReloadErrorMsg{Err: err})
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if w.matches(event) {
+				if debounceTimer != nil {
+					debounceTimer.Stop()
+				}
+				debounceTimer = time.AfterFunc(100*time.Millisecond, func() {
+					select {
+					case reload <- struct{}{}:
+					default:
+					}
+				})
+			}
+		case <-reload:
+			newRoot, deps, err := loadDeck(filename)
+			if err != nil {
+				// Keep watching what we knew about, the file that broke the
+				// deck is likely among them.
+				w.add(deps)
+				reportError(err)
+				continue
+			}
+
+			w.set(deps)
+			p.Send(tui.UpdateSlidesMsg{NewRoot: newRoot})
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			reportError(err)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// newTestWatcher returns a dependency watcher on a temporary directory holding
+// a deck, an image and a directory deck, the directory it created and the
+// dependencies of the deck.
+func newTestWatcher(t *testing.T) (*depWatcher, string, []string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, name := range []string{"deck.md", "img/logo.png", "slides/a.md"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { watcher.Close() })
+
+	deps := []string{
+		filepath.Join(dir, "deck.md"),
+		filepath.Join(dir, "img", "logo.png"),
+		filepath.Join(dir, "slides"),
+	}
+	return newDepWatcher(watcher), dir, deps
+}
+
+func TestDepWatcherMatches(t *testing.T) {
+	w, dir, deps := newTestWatcher(t)
+	w.set(deps)
+
+	tt := []struct {
+		name     string
+		file     string
+		op       fsnotify.Op
+		expected bool
+	}{
+		{"write", "deck.md", fsnotify.Write, true},
+		{"atomic save", "deck.md", fsnotify.Create, true},
+		{"chmod", "deck.md", fsnotify.Chmod, false},
+		{"renamed away", "deck.md", fsnotify.Rename, false},
+		{"removed", "deck.md", fsnotify.Remove, false},
+		{"backup file", "deck.md~", fsnotify.Write, true},
+		{"swap file", "deck.md.swp", fsnotify.Create, true},
+		{"other file", "other.md", fsnotify.Write, false},
+		{"file sharing a prefix", "deck.mdx", fsnotify.Write, false},
+		{"asset", "img/logo.png", fsnotify.Write, true},
+		{"other asset", "img/icon.png", fsnotify.Create, false},
+		{"slide added", "slides/b.md", fsnotify.Create, true},
+		{"slide renamed", "slides/a.md", fsnotify.Rename, true},
+		{"slide removed", "slides/a.md", fsnotify.Remove, true},
+		{"slide chmod", "slides/a.md", fsnotify.Chmod, false},
+		{"hidden slide", "slides/.a.md", fsnotify.Create, false},
+		{"not a slide", "slides/notes.txt", fsnotify.Create, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			event := fsnotify.Event{Name: filepath.Join(dir, filepath.FromSlash(tc.file)), Op: tc.op}
+			if actual := w.matches(event); actual != tc.expected {
+				t.Errorf("expected %t for %s, got %t", tc.expected, event, actual)
+			}
+		})
+	}
+}
+
+func TestDepWatcherSet(t *testing.T) {
+	w, dir, deps := newTestWatcher(t)
+	watched := func() []string {
+		var dirs []string
+		for _, d := range w.watcher.WatchList() {
+			rel, _ := filepath.Rel(dir, d)
+			dirs = append(dirs, filepath.ToSlash(rel))
+		}
+		slices.Sort(dirs)
+		return dirs
+	}
+
+	w.set(deps)
+	if expected, actual := []string{".", "img", "slides"}, watched(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected the parent directories and the directory deck to be watched, got %q", actual)
+	}
+
+	// Dropping dependencies stops watching the directories no longer needed.
+	w.set(deps[:1])
+	if expected, actual := []string{"."}, watched(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected only the deck directory to be watched, got %q", actual)
+	}
+	event := fsnotify.Event{Name: deps[1], Op: fsnotify.Write}
+	if w.matches(event) {
+		t.Errorf("expected %s to no longer match", event)
+	}
+
+	// Directories that don't exist yet are watched once they are created.
+	missing := filepath.Join(dir, "later", "part.md")
+	w.set([]string{deps[0], missing})
+	if expected, actual := []string{"."}, watched(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected the missing directory not to be watched, got %q", actual)
+	}
+	if err := os.Mkdir(filepath.Dir(missing), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	w.set([]string{deps[0], missing})
+	if expected, actual := []string{".", "later"}, watched(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected the created directory to be watched, got %q", actual)
+	}
+	if event := (fsnotify.Event{Name: missing, Op: fsnotify.Create}); !w.matches(event) {
+		t.Errorf("expected %s to match", event)
+	}
+}
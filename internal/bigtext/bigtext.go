@@ -0,0 +1,157 @@
+// Package bigtext renders text as large letters made of block characters.
+package bigtext
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Fonts are the available fonts, from the largest to the smallest.
+var Fonts = []string{"block", "small"}
+
+// DefaultFont is the font used when none is specified.
+const DefaultFont = "block"
+
+// IsFont reports whether font is one of the available fonts.
+func IsFont(font string) bool {
+	return fontIndex(font) >= 0
+}
+
+func fontIndex(font string) int {
+	for i, f := range Fonts {
+		if f == font {
+			return i
+		}
+	}
+	return -1
+}
+
+// Width returns the width of text rendered in font.
+func Width(text, font string) int {
+	width := 0
+	for i, r := range text {
+		if i > 0 {
+			width++
+		}
+		width += len(glyph(r)[0])
+	}
+	if font == "small" {
+		return (width + 1) / 2
+	}
+	return width
+}
+
+// Render renders text in font, one string per line. Unknown fonts render in
+// the default font.
+func Render(text, font string) []string {
+	rows := make([]strings.Builder, glyphHeight)
+	for i, r := range text {
+		g := glyph(r)
+		for y := range rows {
+			if i > 0 {
+				rows[y].WriteByte(' ')
+			}
+			rows[y].WriteString(g[y])
+		}
+	}
+
+	bitmap := make([]string, glyphHeight)
+	for y := range rows {
+		bitmap[y] = rows[y].String()
+	}
+
+	if font == "small" {
+		return quadrants(bitmap)
+	}
+	return blocks(bitmap)
+}
+
+// Fit renders text in the largest font, starting at font, that fits in
+// width, wrapping words over several lines when the text doesn't fit on one
+// line in any font. It reports false when not even a single word fits.
+func Fit(text, font string, width int) ([]string, bool) {
+	fonts := Fonts[max(fontIndex(font), 0):]
+
+	text = strings.Join(strings.Fields(text), " ")
+	for _, f := range fonts {
+		if Width(text, f) <= width {
+			return Render(text, f), true
+		}
+	}
+
+	for _, f := range fonts {
+		lines, ok := wrap(text, f, width)
+		if !ok {
+			continue
+		}
+
+		var out []string
+		for i, line := range lines {
+			if i > 0 {
+				out = append(out, "")
+			}
+			out = append(out, Render(line, f)...)
+		}
+		return out, true
+	}
+
+	return nil, false
+}
+
+// wrap breaks text into lines no wider than width when rendered in font.
+func wrap(text, font string, width int) ([]string, bool) {
+	var lines []string
+	for _, word := range strings.Fields(text) {
+		if Width(word, font) > width {
+			return nil, false
+		}
+
+		if n := len(lines); n > 0 && Width(lines[n-1]+" "+word, font) <= width {
+			lines[n-1] += " " + word
+			continue
+		}
+		lines = append(lines, word)
+	}
+	return lines, true
+}
+
+func glyph(r rune) []string {
+	if g, ok := glyphs[unicode.ToUpper(r)]; ok {
+		return g
+	}
+	return glyphs['?']
+}
+
+func blocks(bitmap []string) []string {
+	lines := make([]string, len(bitmap))
+	for y, row := range bitmap {
+		lines[y] = strings.ReplaceAll(row, "#", "█")
+	}
+	return lines
+}
+
+// quadrantChars are indexed by the lit pixels of a 2x2 cell, top left being
+// the highest bit and bottom right the lowest.
+var quadrantChars = []rune(" ▗▖▄▝▐▞▟▘▚▌▙▀▜▛█")
+
+// quadrants packs 2x2 pixels into every character using quadrant blocks,
+// halving the size of the text in both directions.
+func quadrants(bitmap []string) []string {
+	lit := func(x, y int) int {
+		if y < len(bitmap) && x < len(bitmap[y]) && bitmap[y][x] == '#' {
+			return 1
+		}
+		return 0
+	}
+
+	var lines []string
+	for y := 0; y < len(bitmap); y += 2 {
+		var b strings.Builder
+		for x := 0; x < len(bitmap[y]); x += 2 {
+			i := lit(x, y)<<3 | lit(x+1, y)<<2 | lit(x, y+1)<<1 | lit(x+1, y+1)
+			b.WriteRune(quadrantChars[i])
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
+}
@@ -0,0 +1,103 @@
+package bigtext
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestQuadrants(t *testing.T) {
+	tests := []struct {
+		give []string
+		want string
+	}{
+		{[]string{"  ", "  "}, " "},
+		{[]string{"  ", " #"}, "▗"},
+		{[]string{"  ", "# "}, "▖"},
+		{[]string{"  ", "##"}, "▄"},
+		{[]string{" #", "  "}, "▝"},
+		{[]string{" #", " #"}, "▐"},
+		{[]string{" #", "# "}, "▞"},
+		{[]string{" #", "##"}, "▟"},
+		{[]string{"# ", "  "}, "▘"},
+		{[]string{"# ", " #"}, "▚"},
+		{[]string{"# ", "# "}, "▌"},
+		{[]string{"# ", "##"}, "▙"},
+		{[]string{"##", "  "}, "▀"},
+		{[]string{"##", " #"}, "▜"},
+		{[]string{"##", "# "}, "▛"},
+		{[]string{"##", "##"}, "█"},
+		// Odd sizes are padded with unlit pixels.
+		{[]string{"###"}, "▀▘"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.give, "|"), func(t *testing.T) {
+			if got := strings.Join(quadrants(tt.give), "\n"); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFit(t *testing.T) {
+	block := []string{
+		"█   █ ███",
+		"█   █  █ ",
+		"█████  █ ",
+		"█   █  █ ",
+		"█   █ ███",
+	}
+	small := []string{
+		"▌ ▌▜▘",
+		"▛▀▌▐ ",
+		"▘ ▘▀▘",
+	}
+
+	tests := []struct {
+		name  string
+		text  string
+		font  string
+		width int
+		want  [][]string
+		ok    bool
+	}{
+		{"block fits", "HI", "block", 9, [][]string{block}, true},
+		{"small fallback", "HI", "block", 8, [][]string{small}, true},
+		{"small on one line", "HI HI", "block", 12, [][]string{{"▌ ▌▜▘  ▌ ▌▜▘", "▛▀▌▐   ▛▀▌▐ ", "▘ ▘▀▘  ▘ ▘▀▘"}}, true},
+		{"small font", "HI", "small", 20, [][]string{small}, true},
+		{"unknown font", "HI", "nope", 20, [][]string{block}, true},
+		{"wrapped block", "HI  HI", "block", 10, [][]string{block, {""}, block}, true},
+		{"wrapped small", "HI HI", "block", 8, [][]string{small, {""}, small}, true},
+		{"word too wide", "HI HI", "block", 4, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Fit(tt.text, tt.font, tt.width)
+			var want []string
+			for _, lines := range tt.want {
+				want = append(want, lines...)
+			}
+			if ok != tt.ok || !reflect.DeepEqual(got, want) {
+				t.Errorf("Expected %v %q, got %v %q", tt.ok, want, ok, got)
+			}
+			for _, line := range got {
+				if w := len([]rune(line)); w > tt.width {
+					t.Errorf("Line %q is %d wide, more than %d", line, w, tt.width)
+				}
+			}
+		})
+	}
+}
+
+func TestWidth(t *testing.T) {
+	for _, font := range Fonts {
+		for _, text := range []string{"A", "HI", "Hello, World!", "?"} {
+			lines := Render(text, font)
+			if w := len([]rune(lines[0])); w != Width(text, font) {
+				t.Errorf("%s in %s: expected width %d, rendered %d", text, font, Width(text, font), w)
+			}
+		}
+	}
+}
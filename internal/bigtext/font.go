@@ -0,0 +1,65 @@
+package bigtext
+
+// glyphs are 5 pixels tall bitmaps of the supported characters, every # is a
+// lit pixel. Letters are looked up in upper case.
+var glyphs = map[rune][]string{
+	'A': {" ### ", "#   #", "#####", "#   #", "#   #"},
+	'B': {"#### ", "#   #", "#### ", "#   #", "#### "},
+	'C': {" ####", "#    ", "#    ", "#    ", " ####"},
+	'D': {"#### ", "#   #", "#   #", "#   #", "#### "},
+	'E': {"#####", "#    ", "#### ", "#    ", "#####"},
+	'F': {"#####", "#    ", "#### ", "#    ", "#    "},
+	'G': {" ####", "#    ", "#  ##", "#   #", " ####"},
+	'H': {"#   #", "#   #", "#####", "#   #", "#   #"},
+	'I': {"###", " # ", " # ", " # ", "###"},
+	'J': {"    #", "    #", "    #", "#   #", " ### "},
+	'K': {"#   #", "#  # ", "###  ", "#  # ", "#   #"},
+	'L': {"#    ", "#    ", "#    ", "#    ", "#####"},
+	'M': {"#   #", "## ##", "# # #", "#   #", "#   #"},
+	'N': {"#   #", "##  #", "# # #", "#  ##", "#   #"},
+	'O': {" ### ", "#   #", "#   #", "#   #", " ### "},
+	'P': {"#### ", "#   #", "#### ", "#    ", "#    "},
+	'Q': {" ### ", "#   #", "# # #", "#  # ", " ## #"},
+	'R': {"#### ", "#   #", "#### ", "#  # ", "#   #"},
+	'S': {" ####", "#    ", " ### ", "    #", "#### "},
+	'T': {"#####", "  #  ", "  #  ", "  #  ", "  #  "},
+	'U': {"#   #", "#   #", "#   #", "#   #", " ### "},
+	'V': {"#   #", "#   #", "#   #", " # # ", "  #  "},
+	'W': {"#   #", "#   #", "# # #", "## ##", "#   #"},
+	'X': {"#   #", " # # ", "  #  ", " # # ", "#   #"},
+	'Y': {"#   #", " # # ", "  #  ", "  #  ", "  #  "},
+	'Z': {"#####", "   # ", "  #  ", " #   ", "#####"},
+
+	'0': {" ### ", "#  ##", "# # #", "##  #", " ### "},
+	'1': {" # ", "## ", " # ", " # ", "###"},
+	'2': {" ### ", "#   #", "  ## ", " #   ", "#####"},
+	'3': {"#### ", "    #", " ### ", "    #", "#### "},
+	'4': {"#   #", "#   #", "#####", "    #", "    #"},
+	'5': {"#####", "#    ", "#### ", "    #", "#### "},
+	'6': {" ### ", "#    ", "#### ", "#   #", " ### "},
+	'7': {"#####", "    #", "   # ", "  #  ", "  #  "},
+	'8': {" ### ", "#   #", " ### ", "#   #", " ### "},
+	'9': {" ### ", "#   #", " ####", "    #", " ### "},
+
+	' ':  {"   ", "   ", "   ", "   ", "   "},
+	'!':  {"#", "#", "#", " ", "#"},
+	'?':  {" ### ", "#   #", "  ## ", "     ", "  #  "},
+	'.':  {" ", " ", " ", " ", "#"},
+	',':  {"  ", "  ", "  ", " #", "# "},
+	':':  {" ", "#", " ", "#", " "},
+	';':  {"  ", " #", "  ", " #", "# "},
+	'\'': {"#", "#", " ", " ", " "},
+	'"':  {"# #", "# #", "   ", "   ", "   "},
+	'-':  {"   ", "   ", "###", "   ", "   "},
+	'+':  {"   ", " # ", "###", " # ", "   "},
+	'=':  {"   ", "###", "   ", "###", "   "},
+	'_':  {"   ", "   ", "   ", "   ", "###"},
+	'/':  {"    #", "   # ", "  #  ", " #   ", "#    "},
+	'(':  {" #", "# ", "# ", "# ", " #"},
+	')':  {"# ", " #", " #", " #", "# "},
+	'&':  {" ##  ", "#  # ", " ##  ", "#  # ", " ## #"},
+	'#':  {" # # ", "#####", " # # ", "#####", " # # "},
+	'*':  {"     ", "# # #", " ### ", "# # #", "     "},
+}
+
+const glyphHeight = 5
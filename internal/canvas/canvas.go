@@ -0,0 +1,307 @@
+// Package canvas composes terminal frames cell by cell. A rendered frame is
+// parsed into a grid of styled cells, drawn on with offsets, masks and color
+// blends, and serialized back to ANSI with only the style changes between
+// cells.
+package canvas
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Attr is a set of text attributes.
+type Attr uint8
+
+const (
+	Bold Attr = 1 << iota
+	Faint
+	Italic
+	Underline
+	Blink
+	Reverse
+	Strikethrough
+)
+
+// attrParams are the SGR parameters setting every attribute.
+var attrParams = []struct {
+	attr      Attr
+	set, undo int
+}{
+	{Bold, 1, 22},
+	{Faint, 2, 22},
+	{Italic, 3, 23},
+	{Underline, 4, 24},
+	{Blink, 5, 25},
+	{Reverse, 7, 27},
+	{Strikethrough, 9, 29},
+}
+
+// Style is the look of a cell, including the hyperlink it is part of.
+type Style struct {
+	Fg, Bg Color
+	Attrs  Attr
+	Link   string
+}
+
+// Cell is a grapheme drawn on the canvas. Wide graphemes take two cells, the
+// second one having a zero width.
+type Cell struct {
+	Content string
+	Width   int
+	Style   Style
+}
+
+// Blank is an empty unstyled cell.
+var Blank = Cell{Content: " ", Width: 1}
+
+// Canvas is a grid of cells.
+type Canvas struct {
+	Width, Height int
+	cells         []Cell
+}
+
+// New returns a canvas of blank cells.
+func New(width, height int) *Canvas {
+	c := &Canvas{Width: width, Height: height, cells: make([]Cell, width*height)}
+	for i := range c.cells {
+		c.cells[i] = Blank
+	}
+	return c
+}
+
+// Parse draws the lines of the rendered frame s on a new canvas, clipping
+// them to its size.
+func Parse(s string, width, height int) *Canvas {
+	c := New(width, height)
+	for y, line := range strings.Split(s, "\n") {
+		if y >= height {
+			break
+		}
+
+		var (
+			style Style
+			state byte
+			x     int
+		)
+		for len(line) > 0 && x < width {
+			seq, w, n, newState := ansi.DecodeSequence(line, state, nil)
+			state = newState
+			line = line[n:]
+
+			switch {
+			case w > 0:
+				c.Set(x, y, Cell{Content: seq, Width: w, Style: style})
+				x += w
+			case strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m"):
+				style = style.apply(seq[2 : len(seq)-1])
+			case strings.HasPrefix(seq, "\x1b]8;"):
+				style.Link = hyperlink(seq)
+			}
+		}
+	}
+	return c
+}
+
+// Clone returns a copy of c.
+func (c *Canvas) Clone() *Canvas {
+	clone := *c
+	clone.cells = append([]Cell(nil), c.cells...)
+	return &clone
+}
+
+func (c *Canvas) in(x, y int) bool {
+	return x >= 0 && x < c.Width && y >= 0 && y < c.Height
+}
+
+// Cell returns the cell at x, y, or a blank cell outside of the canvas.
+func (c *Canvas) Cell(x, y int) Cell {
+	if !c.in(x, y) {
+		return Blank
+	}
+	return c.cells[y*c.Width+x]
+}
+
+// Set draws cell at x, y. Wide graphemes partly overwritten are replaced by
+// blanks, and wide cells that don't fit at the right edge are dropped.
+func (c *Canvas) Set(x, y int, cell Cell) {
+	if !c.in(x, y) || cell.Width == 0 {
+		return
+	}
+	if cell.Width > 1 && x+1 >= c.Width {
+		cell = Cell{Content: " ", Width: 1, Style: cell.Style}
+	}
+
+	c.split(x, y)
+	if cell.Width > 1 {
+		c.split(x+1, y)
+	}
+
+	i := y*c.Width + x
+	c.cells[i] = cell
+	if cell.Width > 1 {
+		c.cells[i+1] = Cell{Style: cell.Style}
+	}
+}
+
+// split replaces the wide grapheme covering x, y with blanks.
+func (c *Canvas) split(x, y int) {
+	i := y*c.Width + x
+	switch cell := c.cells[i]; {
+	case cell.Width == 0 && x > 0:
+		c.cells[i-1] = Cell{Content: " ", Width: 1, Style: c.cells[i-1].Style}
+		c.cells[i] = Cell{Content: " ", Width: 1, Style: cell.Style}
+	case cell.Width > 1 && x+1 < c.Width:
+		c.cells[i+1] = Cell{Content: " ", Width: 1, Style: cell.Style}
+	}
+}
+
+// Draw draws src on c with its top left corner at x, y.
+func (c *Canvas) Draw(src *Canvas, x, y int) {
+	c.DrawFunc(src, x, y, func(int, int) bool { return true })
+}
+
+// DrawFunc draws src on c with its top left corner at x, y, keeping only the
+// cells for which keep, given their position on c, returns true.
+func (c *Canvas) DrawFunc(src *Canvas, x, y int, keep func(x, y int) bool) {
+	for sy := 0; sy < src.Height; sy++ {
+		for sx := 0; sx < src.Width; sx++ {
+			cell := src.cells[sy*src.Width+sx]
+			if cell.Width == 0 || !keep(x+sx, y+sy) {
+				continue
+			}
+			c.Set(x+sx, y+sy, cell)
+		}
+	}
+}
+
+// String serializes the canvas, one line per row, emitting only the style
+// changes between cells and resetting the style at the end of every line.
+func (c *Canvas) String() string {
+	var b strings.Builder
+	for y := 0; y < c.Height; y++ {
+		if y > 0 {
+			b.WriteByte('\n')
+		}
+
+		var cur Style
+		for _, cell := range c.cells[y*c.Width : (y+1)*c.Width] {
+			if cell.Width == 0 {
+				continue
+			}
+			if cell.Style != cur {
+				b.WriteString(transition(cur, cell.Style))
+				cur = cell.Style
+			}
+			b.WriteString(cell.Content)
+		}
+		b.WriteString(transition(cur, Style{}))
+	}
+	return b.String()
+}
+
+// transition returns the sequences changing the style from one to another.
+func transition(from, to Style) string {
+	var out string
+	if from.Link != to.Link {
+		out = ansi.SetHyperlink(to.Link)
+	}
+
+	from.Link, to.Link = "", ""
+	if from == to {
+		return out
+	}
+
+	var params []string
+	if from.Attrs&^to.Attrs != 0 || !from.Fg.IsDefault() && to.Fg.IsDefault() || !from.Bg.IsDefault() && to.Bg.IsDefault() {
+		// Something is turned off, start over.
+		if to == (Style{}) {
+			return out + "\x1b[0m"
+		}
+		params = append(params, "0")
+		from = Style{}
+	}
+	for _, a := range attrParams {
+		if to.Attrs&a.attr != 0 && from.Attrs&a.attr == 0 {
+			params = append(params, strconv.Itoa(a.set))
+		}
+	}
+	if to.Fg != from.Fg {
+		params = append(params, to.Fg.params(false))
+	}
+	if to.Bg != from.Bg {
+		params = append(params, to.Bg.params(true))
+	}
+	return out + "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// apply returns the style after the SGR sequence with the given parameters.
+func (s Style) apply(params string) Style {
+	if params == "" {
+		return Style{Link: s.Link}
+	}
+
+	p := strings.FieldsFunc(params, func(r rune) bool { return r == ';' || r == ':' })
+	num := func(i int) int {
+		if i >= len(p) {
+			return 0
+		}
+		n, _ := strconv.Atoi(p[i])
+		return n
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch n := num(i); {
+		case n == 0:
+			s = Style{Link: s.Link}
+		case n == 22:
+			s.Attrs &^= Bold | Faint
+		case n >= 30 && n <= 37:
+			s.Fg = Indexed(uint8(n - 30))
+		case n >= 90 && n <= 97:
+			s.Fg = Indexed(uint8(n - 90 + 8))
+		case n >= 40 && n <= 47:
+			s.Bg = Indexed(uint8(n - 40))
+		case n >= 100 && n <= 107:
+			s.Bg = Indexed(uint8(n - 100 + 8))
+		case n == 39:
+			s.Fg = Color{}
+		case n == 49:
+			s.Bg = Color{}
+		case n == 38 || n == 48:
+			var c Color
+			switch num(i + 1) {
+			case 5:
+				c = Indexed(uint8(num(i + 2)))
+				i += 2
+			case 2:
+				c = RGB(uint8(num(i+2)), uint8(num(i+3)), uint8(num(i+4)))
+				i += 4
+			}
+			if n == 38 {
+				s.Fg = c
+			} else {
+				s.Bg = c
+			}
+		default:
+			for _, a := range attrParams {
+				switch n {
+				case a.set:
+					s.Attrs |= a.attr
+				case a.undo:
+					s.Attrs &^= a.attr
+				}
+			}
+		}
+	}
+	return s
+}
+
+// hyperlink returns the URI of an OSC 8 sequence, empty when it closes the
+// link.
+func hyperlink(seq string) string {
+	body := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(seq, "\x1b]8;"), "\a"), "\x1b\\")
+	_, uri, _ := strings.Cut(body, ";")
+	return uri
+}
@@ -0,0 +1,80 @@
+package canvas
+
+import (
+	"testing"
+)
+
+func TestParseString(t *testing.T) {
+	tt := []struct {
+		name          string
+		input         string
+		width, height int
+		expected      string
+	}{
+		{"plain", "ab\ncd", 3, 2, "ab \ncd "},
+		{"clipped", "abcd\nef\ngh", 2, 2, "ab\nef"},
+		{"styled", "\x1b[1;31mab\x1b[0mc", 3, 1, "\x1b[1;31mab\x1b[0mc"},
+		{"unchanged style", "\x1b[31ma\x1b[31mb", 2, 1, "\x1b[31mab\x1b[0m"},
+		{"added attribute", "\x1b[31ma\x1b[1mb", 2, 1, "\x1b[31ma\x1b[1mb\x1b[0m"},
+		{"removed attribute", "\x1b[1;31ma\x1b[22mb", 2, 1, "\x1b[1;31ma\x1b[0;31mb\x1b[0m"},
+		{"extended colors", "\x1b[38;5;228;48;2;1;2;3ma", 1, 1, "\x1b[38;5;228;48;2;1;2;3ma\x1b[0m"},
+		{"bright colors", "\x1b[91;104ma", 1, 1, "\x1b[91;104ma\x1b[0m"},
+		{"style per line", "\x1b[31ma\nb", 1, 2, "\x1b[31ma\x1b[0m\nb"},
+		{"hyperlink", "\x1b]8;;https://x.y\aab\x1b]8;;\ac", 3, 1, "\x1b]8;;https://x.y\aab\x1b]8;;\ac"},
+		{"wide", "日本", 4, 1, "日本"},
+		{"wide at edge", "a日", 2, 1, "a "},
+		{"grapheme cluster", "👩‍💻a", 3, 1, "👩‍💻a"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := Parse(tc.input, tc.width, tc.height).String()
+			if actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDraw(t *testing.T) {
+	c := Parse("日本語", 6, 1)
+	c.Draw(Parse("ab", 2, 1), 1, 0)
+	if s := c.String(); s != " ab 語" {
+		t.Errorf("expected %q, got %q", " ab 語", s)
+	}
+
+	c = New(4, 1)
+	c.Draw(Parse("日本", 4, 1), -1, 0)
+	if s := c.String(); s != " 本 " {
+		t.Errorf("expected %q, got %q", " 本 ", s)
+	}
+
+	c = New(4, 1)
+	c.DrawFunc(Parse("abcd", 4, 1), 0, 0, func(x, _ int) bool { return x%2 == 0 })
+	if s := c.String(); s != "a c " {
+		t.Errorf("expected %q, got %q", "a c ", s)
+	}
+}
+
+func TestBlend(t *testing.T) {
+	tt := []struct {
+		name     string
+		a, b     Color
+		t        float64
+		expected Color
+	}{
+		{"start", RGB(0, 0, 0), RGB(255, 255, 255), 0, RGB(0, 0, 0)},
+		{"middle", RGB(0, 0, 0), RGB(255, 100, 10), 0.5, RGB(128, 50, 5)},
+		{"indexed", Indexed(16), Indexed(231), 1, RGB(255, 255, 255)},
+		{"default before half", Color{}, RGB(255, 255, 255), 0.4, Color{}},
+		{"default after half", Color{}, RGB(255, 255, 255), 0.6, RGB(255, 255, 255)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := Blend(tc.a, tc.b, tc.t); actual != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package canvas
+
+import (
+	"math"
+	"strconv"
+)
+
+type colorKind uint8
+
+const (
+	defaultColor colorKind = iota
+	indexedColor
+	rgbColor
+)
+
+// Color is a terminal color: the default color, one of the 256 indexed colors
+// or a true color.
+type Color struct {
+	kind    colorKind
+	index   uint8
+	r, g, b uint8
+}
+
+// Indexed returns one of the 256 indexed colors, the first 16 being the
+// basic and bright colors of the terminal theme.
+func Indexed(i uint8) Color {
+	return Color{kind: indexedColor, index: i}
+}
+
+// RGB returns a true color.
+func RGB(r, g, b uint8) Color {
+	return Color{kind: rgbColor, r: r, g: g, b: b}
+}
+
+// IsDefault reports whether c is the default color of the terminal.
+func (c Color) IsDefault() bool {
+	return c.kind == defaultColor
+}
+
+// basic approximates the 16 theme colors with the xterm defaults.
+var basic = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// RGB returns the red, green and blue components of c, reporting false for
+// the default color which is up to the terminal.
+func (c Color) RGB() (r, g, b uint8, ok bool) {
+	switch c.kind {
+	case rgbColor:
+		return c.r, c.g, c.b, true
+	case indexedColor:
+		switch i := int(c.index); {
+		case i < 16:
+			return basic[i][0], basic[i][1], basic[i][2], true
+		case i < 232:
+			// 6x6x6 color cube.
+			level := func(v int) uint8 {
+				if v == 0 {
+					return 0
+				}
+				return uint8(55 + v*40)
+			}
+			i -= 16
+			return level(i / 36), level(i / 6 % 6), level(i % 6), true
+		default:
+			v := uint8(8 + (i-232)*10)
+			return v, v, v, true
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// Blend mixes a and b, t going from 0 for a to 1 for b. The default color
+// can't be mixed, blends involving it switch from a to b halfway.
+func Blend(a, b Color, t float64) Color {
+	t = math.Max(0, math.Min(1, t))
+	ar, ag, ab, aok := a.RGB()
+	br, bg, bb, bok := b.RGB()
+	if !aok || !bok {
+		if t < 0.5 {
+			return a
+		}
+		return b
+	}
+
+	mix := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + (float64(y)-float64(x))*t))
+	}
+	return RGB(mix(ar, br), mix(ag, bg), mix(ab, bb))
+}
+
+// params returns the SGR parameters setting c as the foreground color, or
+// as the background color when bg is set.
+func (c Color) params(bg bool) string {
+	base := 30
+	if bg {
+		base = 40
+	}
+	switch c.kind {
+	case indexedColor:
+		switch {
+		case c.index < 8:
+			return strconv.Itoa(base + int(c.index))
+		case c.index < 16:
+			return strconv.Itoa(base + 60 + int(c.index) - 8)
+		}
+		return strconv.Itoa(base+8) + ";5;" + strconv.Itoa(int(c.index))
+	case rgbColor:
+		return strconv.Itoa(base+8) + ";2;" + strconv.Itoa(int(c.r)) + ";" + strconv.Itoa(int(c.g)) + ";" + strconv.Itoa(int(c.b))
+	}
+	return strconv.Itoa(base + 9)
+}
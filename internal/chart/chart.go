@@ -0,0 +1,149 @@
+// Package chart draws bar charts, line charts and sparklines from CSV data
+// as text.
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// Kinds of charts.
+var Kinds = []string{"bar", "line", "sparkline"}
+
+// DefaultHeight is the height of line charts in rows when none is given.
+const DefaultHeight = 10
+
+// Style colors a chart. Series are colored in order, cycling through Series.
+type Style struct {
+	Series []lipgloss.Style
+	Text   lipgloss.Style
+}
+
+func (s Style) series(i int) lipgloss.Style {
+	if len(s.Series) == 0 {
+		return lipgloss.NewStyle()
+	}
+	return s.Series[i%len(s.Series)]
+}
+
+// Render draws the CSV data in src as a chart of the given kind, width wide.
+// Height only applies to line charts.
+func Render(kind, src string, width, height int, style Style) ([]string, error) {
+	d, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case "bar":
+		return bar(d, width, style), nil
+	case "line":
+		return line(d, width, height, style), nil
+	case "sparkline":
+		return sparklines(d, width, style), nil
+	}
+	return nil, fmt.Errorf("unknown chart %q, expected one of %s", kind, strings.Join(Kinds, ", "))
+}
+
+// legend names the series in their colors, when there is more than one.
+func legend(d data, style Style) []string {
+	if len(d.series) < 2 {
+		return nil
+	}
+	var parts []string
+	for i, s := range d.series {
+		name := s.name
+		if name == "" {
+			name = fmt.Sprintf("series %d", i+1)
+		}
+		parts = append(parts, style.series(i).Render("■")+" "+style.Text.Render(name))
+	}
+	return []string{strings.Join(parts, "  "), ""}
+}
+
+var eighths = []rune(" ▏▎▍▌▋▊▉█")
+
+// bar draws a horizontal bar per label and series, scaled to the largest
+// value. Negative values are drawn as empty bars.
+func bar(d data, width int, style Style) []string {
+	labelWidth, valueWidth := 0, 0
+	for i, label := range d.labels {
+		labelWidth = max(labelWidth, runewidth.StringWidth(label))
+		for _, s := range d.series {
+			valueWidth = max(valueWidth, len(format(s.values[i])))
+		}
+	}
+
+	_, hi := d.bounds()
+	room := max(width-labelWidth-valueWidth-2, 1)
+
+	out := legend(d, style)
+	for i, label := range d.labels {
+		for j, s := range d.series {
+			v := s.values[i]
+			n := 0
+			if hi > 0 && v > 0 {
+				n = min(int(math.Round(v/hi*float64(room*8))), room*8)
+			}
+			b := strings.Repeat("█", n/8)
+			if n%8 > 0 {
+				b += string(eighths[n%8])
+			}
+
+			if j > 0 {
+				label = ""
+			}
+			out = append(out, style.Text.Render(runewidth.FillRight(label, labelWidth))+" "+
+				style.series(j).Render(b)+" "+style.Text.Render(format(v)))
+		}
+	}
+	return out
+}
+
+var ticks = []rune("▁▂▃▄▅▆▇█")
+
+// sparklines draws a line of ticks per series, showing the latest values
+// that fit.
+func sparklines(d data, width int, style Style) []string {
+	nameWidth, valueWidth := 0, 0
+	for _, s := range d.series {
+		nameWidth = max(nameWidth, runewidth.StringWidth(s.name))
+		valueWidth = max(valueWidth, len(format(s.values[len(s.values)-1])))
+	}
+
+	room := max(width-valueWidth-1, 1)
+	if nameWidth > 0 {
+		room = max(room-nameWidth-1, 1)
+	}
+
+	var out []string
+	for i, s := range d.series {
+		values := s.values[max(len(s.values)-room, 0):]
+		lo, hi := values[0], values[0]
+		for _, v := range values {
+			lo, hi = min(lo, v), max(hi, v)
+		}
+
+		var b strings.Builder
+		for _, v := range values {
+			t := len(ticks) / 2
+			if hi > lo {
+				t = int(math.Round((v - lo) / (hi - lo) * float64(len(ticks)-1)))
+				t = min(max(t, 0), len(ticks)-1)
+			}
+			b.WriteRune(ticks[t])
+		}
+
+		var line string
+		if nameWidth > 0 {
+			line = style.Text.Render(runewidth.FillRight(s.name, nameWidth)) + " "
+		}
+		line += style.series(i).Render(b.String()) + " " + style.Text.Render(format(s.values[len(s.values)-1]))
+		out = append(out, line)
+	}
+	return out
+}
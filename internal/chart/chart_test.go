@@ -0,0 +1,156 @@
+package chart
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    string
+		expected data
+		err      string
+	}{
+		{
+			name:     "labels",
+			input:    "a,1\nb,2.5",
+			expected: data{labels: []string{"a", "b"}, series: []series{{values: []float64{1, 2.5}}}},
+		},
+		{
+			name:  "header",
+			input: "month,x,y\njan,1,2\nfeb,3",
+			expected: data{
+				labels: []string{"jan", "feb"},
+				series: []series{{name: "x", values: []float64{1, 3}}, {name: "y", values: []float64{2, 0}}},
+			},
+		},
+		{
+			name:     "values only",
+			input:    "1\n# comment\n\n2",
+			expected: data{labels: []string{"", ""}, series: []series{{values: []float64{1, 2}}}},
+		},
+		{name: "empty", input: "", err: "no data"},
+		{name: "header only", input: "x,y", err: "no data"},
+		{name: "invalid", input: "a,1\nb,oops", err: `row 2, column 2: invalid value "oops"`},
+		{name: "nan", input: "NaN", err: `row 1, column 1: value "NaN" is not a finite number`},
+		{name: "infinity", input: "a,Inf\nb,1", err: `row 1, column 2: value "Inf" is not a finite number`},
+		{name: "negative infinity", input: "a,1,-Infinity", err: `row 1, column 3: value "-Infinity" is not a finite number`},
+		{name: "overflowing range", input: "a,1e308\nb,-1e308", err: "values span too large a range to plot"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := parse(tc.input)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tt := []struct {
+		name     string
+		kind     string
+		input    string
+		height   int
+		expected []string
+	}{
+		{
+			name:     "bar",
+			kind:     "bar",
+			input:    "a,1\nb,3\nc,2",
+			expected: []string{"a █████▍ 1", "b ████████████████ 3", "c ██████████▋ 2"},
+		},
+		{
+			name:     "bar negative",
+			kind:     "bar",
+			input:    "x,y,z\na,1,2\nb,4,-1",
+			expected: []string{"■ y  ■ z", "", "a ███▊ 1", "  ███████▌ 2", "b ███████████████ 4", "   -1"},
+		},
+		{
+			name:   "line",
+			kind:   "line",
+			input:  "a,1\nb,3\nc,2",
+			height: 4,
+			expected: []string{
+				"3 ┤       ⡠⠊⠒⠤⣀     ",
+				"  │    ⢀⠔⠉     ⠉⠒⠤⣀ ",
+				"  │  ⣀⠔⠁           ⠉",
+				"1 ┤⡠⠊               ",
+				"  └─────────────────",
+				"  a                c",
+			},
+		},
+		{
+			name:   "line large values",
+			kind:   "line",
+			input:  "a,1e308\nb,1e307",
+			height: 4,
+			expected: []string{
+				"1e+308 ┤⠑⠢⣀         ",
+				"       │   ⠑⠢⣀      ",
+				"       │      ⠉⠢⢄   ",
+				"1e+307 ┤         ⠉⠢⢄",
+				"       └────────────",
+				"       a           b",
+			},
+		},
+		{
+			name:     "sparkline",
+			kind:     "sparkline",
+			input:    "a,1\nb,3\nc,2",
+			expected: []string{"▁█▅ 2"},
+		},
+		{
+			name:     "sparkline constant",
+			kind:     "sparkline",
+			input:    "x\n5\n5",
+			expected: []string{"x ▅▅ 5"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Render(tc.kind, tc.input, 20, tc.height, Style{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected\n%s\ngot\n%s", strings.Join(tc.expected, "\n"), strings.Join(actual, "\n"))
+			}
+		})
+	}
+}
+
+func TestRenderInvalid(t *testing.T) {
+	tt := []struct {
+		name  string
+		kind  string
+		input string
+	}{
+		{"bar infinity", "bar", "a,Inf\nb,1"},
+		{"sparkline infinity", "sparkline", "a,Inf\nb,1"},
+		{"line overflowing range", "line", "a,1e308\nb,-1e308"},
+		{"unknown kind", "pie", "a,1"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Render(tc.kind, tc.input, 20, 4, Style{}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
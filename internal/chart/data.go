@@ -0,0 +1,115 @@
+package chart
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type series struct {
+	name   string
+	values []float64
+}
+
+// data is a table of values, one row per label and one column per series.
+type data struct {
+	labels []string
+	series []series
+}
+
+// parse reads CSV data with an optional header row. The first column holds
+// the labels and every other column a series, a single column holds the
+// values of an unlabeled series.
+func parse(src string) (data, error) {
+	r := csv.NewReader(strings.NewReader(src))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	r.Comment = '#'
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return data{}, err
+	}
+
+	var d data
+	for i, record := range records {
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		label, fields, first := "", record, 1
+		if len(record) > 1 {
+			label, fields, first = strings.TrimSpace(record[0]), record[1:], 2
+		}
+
+		values := make([]float64, len(fields))
+		for j, field := range fields {
+			v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil {
+				if i == 0 && d.series == nil {
+					// Header row naming the series.
+					values = nil
+					break
+				}
+				return data{}, fmt.Errorf("row %d, column %d: invalid value %q", i+1, first+j, field)
+			}
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return data{}, fmt.Errorf("row %d, column %d: value %q is not a finite number", i+1, first+j, field)
+			}
+			values[j] = v
+		}
+
+		for len(d.series) < len(fields) {
+			d.series = append(d.series, series{values: make([]float64, len(d.labels))})
+		}
+		if values == nil {
+			for j, field := range fields {
+				d.series[j].name = strings.TrimSpace(field)
+			}
+			continue
+		}
+
+		d.labels = append(d.labels, label)
+		for j := range d.series {
+			var v float64
+			if j < len(values) {
+				v = values[j]
+			}
+			d.series[j].values = append(d.series[j].values, v)
+		}
+	}
+
+	if len(d.labels) == 0 {
+		return data{}, errors.New("no data")
+	}
+	if lo, hi := d.bounds(); math.IsInf(hi-lo, 0) {
+		return data{}, errors.New("values span too large a range to plot")
+	}
+	return d, nil
+}
+
+// bounds returns the smallest and largest values of all series.
+func (d data) bounds() (float64, float64) {
+	lo, hi := d.series[0].values[0], d.series[0].values[0]
+	for _, s := range d.series {
+		for _, v := range s.values {
+			lo, hi = min(lo, v), max(hi, v)
+		}
+	}
+	return lo, hi
+}
+
+// format formats a value for axes and labels, dropping needless decimals.
+func format(v float64) string {
+	if math.Abs(v) >= 1e15 {
+		return strconv.FormatFloat(v, 'g', 3, 64)
+	}
+	if v == float64(int64(v)) {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	return strings.TrimRight(strings.TrimRight(s, "0"), ".")
+}
@@ -0,0 +1,163 @@
+package chart
+
+import (
+	"math"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// brailleDots maps the column and row of a dot in a braille cell, two dots
+// wide and four tall, to its bit.
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// plot is a grid of braille cells, remembering which series last drew in
+// each cell to color it.
+type plot struct {
+	dots  [][]rune
+	owner [][]int
+}
+
+func newPlot(cols, rows int) *plot {
+	p := &plot{dots: make([][]rune, rows), owner: make([][]int, rows)}
+	for y := range p.dots {
+		p.dots[y] = make([]rune, cols)
+		p.owner[y] = make([]int, cols)
+	}
+	return p
+}
+
+func (p *plot) dot(x, y, series int) {
+	row, col := y/4, x/2
+	if row < 0 || row >= len(p.dots) || col < 0 || col >= len(p.dots[row]) {
+		return
+	}
+	p.dots[row][col] |= brailleDots[x%2][y%4]
+	p.owner[row][col] = series
+}
+
+// segment draws the dots between two points with Bresenham's algorithm.
+func (p *plot) segment(x0, y0, x1, y1, series int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		p.dot(x0, y0, series)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+// line draws the series as lines of braille dots over a height rows tall
+// plot with the value axis on the left and the first and last labels below.
+func line(d data, width, height int, style Style) []string {
+	if height <= 0 {
+		height = DefaultHeight
+	}
+
+	lo, hi := d.bounds()
+	if lo == hi {
+		lo, hi = lo-1, hi+1
+	}
+	axis := map[int]string{0: format(hi), height - 1: format(lo)}
+	if height >= 5 {
+		axis[(height-1)/2] = format((hi + lo) / 2)
+	}
+	axisWidth := 0
+	for _, label := range axis {
+		axisWidth = max(axisWidth, len(label))
+	}
+
+	cols := max(width-axisWidth-2, 2)
+	p := newPlot(cols, height)
+	w, h := cols*2-1, height*4-1
+	point := func(i int, v float64) (int, int) {
+		x := 0
+		if n := len(d.labels); n > 1 {
+			x = int(math.Round(float64(i*w) / float64(n-1)))
+		}
+		y := int(math.Round((hi - v) / (hi - lo) * float64(h)))
+		return x, min(max(y, 0), h)
+	}
+	for j, s := range d.series {
+		x0, y0 := point(0, s.values[0])
+		p.dot(x0, y0, j)
+		for i := 1; i < len(s.values); i++ {
+			x1, y1 := point(i, s.values[i])
+			p.segment(x0, y0, x1, y1, j)
+			x0, y0 = x1, y1
+		}
+	}
+
+	out := legend(d, style)
+	for y, row := range p.dots {
+		tick := "│"
+		if _, ok := axis[y]; ok {
+			tick = "┤"
+		}
+
+		var b strings.Builder
+		b.WriteString(style.Text.Render(strings.Repeat(" ", axisWidth-len(axis[y])) + axis[y] + " " + tick))
+		for x := 0; x < len(row); {
+			// Style runs of cells drawn by the same series together.
+			end := x + 1
+			for end < len(row) && (row[end] == 0) == (row[x] == 0) && p.owner[y][end] == p.owner[y][x] {
+				end++
+			}
+			var run strings.Builder
+			for _, r := range row[x:end] {
+				if r == 0 {
+					run.WriteRune(' ')
+				} else {
+					run.WriteRune(0x2800 + r)
+				}
+			}
+			if row[x] == 0 {
+				b.WriteString(run.String())
+			} else {
+				b.WriteString(style.series(p.owner[y][x]).Render(run.String()))
+			}
+			x = end
+		}
+		out = append(out, b.String())
+	}
+
+	indent := strings.Repeat(" ", axisWidth+1)
+	out = append(out, style.Text.Render(indent+"└"+strings.Repeat("─", cols)))
+
+	first, last := d.labels[0], d.labels[len(d.labels)-1]
+	gap := cols + 1 - runewidth.StringWidth(first) - runewidth.StringWidth(last)
+	switch {
+	case len(d.labels) > 1 && gap > 0:
+		out = append(out, style.Text.Render(indent+first+strings.Repeat(" ", gap)+last))
+	case first != "":
+		out = append(out, style.Text.Render(indent+first))
+	}
+	return out
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,257 @@
+package diagram
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// Style colors the parts of a diagram.
+type Style struct {
+	Node  lipgloss.Style
+	Edge  lipgloss.Style
+	Label lipgloss.Style
+}
+
+type kind int
+
+const (
+	empty kind = iota
+	node
+	edge
+	label
+)
+
+// Directions a line leaves a cell in, combined into a mask to pick the box
+// drawing character joining them.
+const (
+	up = 1 << iota
+	right
+	down
+	left
+)
+
+var joints = map[int]rune{
+	up:                       '│',
+	down:                     '│',
+	up | down:                '│',
+	left:                     '─',
+	right:                    '─',
+	left | right:             '─',
+	down | right:             '┌',
+	down | left:              '┐',
+	up | right:               '└',
+	up | left:                '┘',
+	up | down | right:        '├',
+	up | down | left:         '┤',
+	left | right | down:      '┬',
+	left | right | up:        '┴',
+	up | down | left | right: '┼',
+}
+
+type lineStyle int
+
+const (
+	solid lineStyle = iota
+	dotted
+	thick
+)
+
+type cell struct {
+	r    rune
+	kind kind
+	mask int
+	line lineStyle
+}
+
+// canvas is a grid of cells diagrams are drawn on. Wide characters take two
+// cells, the second one holding a zero rune.
+type canvas struct {
+	cells [][]cell
+}
+
+func newCanvas(width, height int) *canvas {
+	c := &canvas{cells: make([][]cell, height)}
+	for y := range c.cells {
+		c.cells[y] = make([]cell, width)
+	}
+	return c
+}
+
+func (c *canvas) in(x, y int) bool {
+	return y >= 0 && y < len(c.cells) && x >= 0 && x < len(c.cells[y])
+}
+
+func (c *canvas) set(x, y int, r rune, k kind) {
+	if c.in(x, y) {
+		c.cells[y][x] = cell{r: r, kind: k}
+	}
+}
+
+// text writes s starting at x, y.
+func (c *canvas) text(x, y int, s string, k kind) {
+	for _, r := range s {
+		w := runewidth.RuneWidth(r)
+		if w == 0 {
+			continue
+		}
+		c.set(x, y, r, k)
+		if w == 2 {
+			c.set(x+1, y, 0, k)
+		}
+		x += w
+	}
+}
+
+// connect adds the directions in mask to the line through the cell, unless
+// something other than a line is drawn there.
+func (c *canvas) connect(x, y, mask int, style lineStyle) {
+	if mask == 0 || !c.in(x, y) {
+		return
+	}
+	cl := &c.cells[y][x]
+	if cl.kind != empty && cl.kind != edge || cl.kind == edge && cl.mask == 0 {
+		return
+	}
+	if cl.kind == edge && cl.line != style {
+		style = solid
+	}
+	*cl = cell{kind: edge, mask: cl.mask | mask, line: style}
+}
+
+// hline draws a horizontal line between x1 and x2 inclusive on row y.
+func (c *canvas) hline(x1, x2, y int, style lineStyle) {
+	if x1 > x2 {
+		x1, x2 = x2, x1
+	}
+	for x := x1; x <= x2; x++ {
+		mask := 0
+		if x > x1 {
+			mask |= left
+		}
+		if x < x2 {
+			mask |= right
+		}
+		c.connect(x, y, mask, style)
+	}
+}
+
+// vline draws a vertical line between y1 and y2 inclusive on column x.
+func (c *canvas) vline(x, y1, y2 int, style lineStyle) {
+	if y1 > y2 {
+		y1, y2 = y2, y1
+	}
+	for y := y1; y <= y2; y++ {
+		mask := 0
+		if y > y1 {
+			mask |= up
+		}
+		if y < y2 {
+			mask |= down
+		}
+		c.connect(x, y, mask, style)
+	}
+}
+
+var borders = map[string][6]rune{
+	"rect":  {'┌', '┐', '└', '┘', '─', '│'},
+	"round": {'╭', '╮', '╰', '╯', '─', '│'},
+	"rhomb": {'╔', '╗', '╚', '╝', '═', '║'},
+}
+
+// box draws a box with the label lines centered inside of it.
+func (c *canvas) box(x, y, w, h int, shape string, lines []string) {
+	b, ok := borders[shape]
+	if !ok {
+		b = borders["rect"]
+	}
+
+	for i := 1; i < w-1; i++ {
+		c.set(x+i, y, b[4], node)
+		c.set(x+i, y+h-1, b[4], node)
+	}
+	for i := 1; i < h-1; i++ {
+		c.set(x, y+i, b[5], node)
+		c.set(x+w-1, y+i, b[5], node)
+		for j := 1; j < w-1; j++ {
+			c.set(x+j, y+i, ' ', node)
+		}
+	}
+	c.set(x, y, b[0], node)
+	c.set(x+w-1, y, b[1], node)
+	c.set(x, y+h-1, b[2], node)
+	c.set(x+w-1, y+h-1, b[3], node)
+
+	for i, line := range lines {
+		c.text(x+(w-runewidth.StringWidth(line))/2, y+1+i, line, node)
+	}
+}
+
+func (cl cell) rune() rune {
+	if cl.kind != edge || cl.mask == 0 {
+		if cl.r == 0 && cl.kind == empty {
+			return ' '
+		}
+		return cl.r
+	}
+
+	switch {
+	case cl.line == dotted && cl.mask&(up|down) == cl.mask:
+		return '┆'
+	case cl.line == dotted && cl.mask&(left|right) == cl.mask:
+		return '┄'
+	case cl.line == thick && cl.mask&(up|down) == cl.mask:
+		return '┃'
+	case cl.line == thick && cl.mask&(left|right) == cl.mask:
+		return '━'
+	}
+	return joints[cl.mask]
+}
+
+// lines serializes the canvas, styling runs of cells of the same kind.
+func (c *canvas) lines(style Style) []string {
+	styles := map[kind]lipgloss.Style{
+		node:  style.Node,
+		edge:  style.Edge,
+		label: style.Label,
+	}
+
+	out := make([]string, len(c.cells))
+	for y, row := range c.cells {
+		// Trailing empty cells aren't part of the diagram.
+		end := len(row)
+		for end > 0 && row[end-1].kind == empty {
+			end--
+		}
+
+		var (
+			b   strings.Builder
+			run strings.Builder
+			k   kind
+		)
+		flush := func() {
+			if s, ok := styles[k]; ok {
+				b.WriteString(s.Render(run.String()))
+			} else {
+				b.WriteString(run.String())
+			}
+			run.Reset()
+		}
+		for x := 0; x < end; x++ {
+			cl := row[x]
+			if cl.kind != k {
+				flush()
+				k = cl.kind
+			}
+			if cl.r == 0 && cl.kind != empty && cl.kind != edge {
+				// Second half of a wide character.
+				continue
+			}
+			run.WriteRune(cl.rune())
+		}
+		flush()
+		out[y] = b.String()
+	}
+	return out
+}
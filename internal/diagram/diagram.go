@@ -0,0 +1,74 @@
+// Package diagram draws flowcharts and sequence diagrams written in a subset
+// of the mermaid syntax as box drawing text.
+package diagram
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Render draws the diagram described by src. Flowcharts that are wider than
+// width are laid out in the other direction when that makes them narrower.
+func Render(src string, width int, style Style) ([]string, error) {
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "%%") {
+			line = ""
+		}
+		lines[i] = line
+	}
+
+	header := 0
+	for header < len(lines) && lines[header] == "" {
+		header++
+	}
+	if header == len(lines) {
+		return nil, fmt.Errorf("empty diagram")
+	}
+	body, start := lines[header+1:], header+2
+
+	// Flowchart statements can follow the header on the same line.
+	if h, rest, ok := strings.Cut(lines[header], ";"); ok && flowchartRe.MatchString(strings.TrimSpace(h)) {
+		lines[header] = strings.TrimSpace(h)
+		body, start = append([]string{rest}, body...), header+1
+	}
+
+	switch {
+	case flowchartRe.MatchString(lines[header]):
+		f, err := parseFlowchart(lines[header], body, start)
+		if err != nil {
+			return nil, err
+		}
+
+		out := f.render(f.dir).lines(style)
+		if widest(out) > width {
+			other := "LR"
+			if f.dir == "LR" {
+				other = "TD"
+			}
+			if alt := f.render(other).lines(style); widest(alt) < widest(out) {
+				out = alt
+			}
+		}
+		return out, nil
+	case lines[header] == "sequenceDiagram":
+		s, err := parseSequence(body, start)
+		if err != nil {
+			return nil, err
+		}
+		return s.render().lines(style), nil
+	}
+
+	return nil, fmt.Errorf("line %d: unsupported diagram %q, expected graph, flowchart or sequenceDiagram", header+1, lines[header])
+}
+
+func widest(lines []string) int {
+	w := 0
+	for _, line := range lines {
+		w = max(w, ansi.StringWidth(line))
+	}
+	return w
+}
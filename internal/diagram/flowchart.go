@@ -0,0 +1,602 @@
+package diagram
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+var (
+	flowchartRe = regexp.MustCompile(`^(graph|flowchart)(\s+(TD|TB|BT|LR|RL))?\s*;?$`)
+	nodeRe      = regexp.MustCompile(`^([\p{L}\p{N}_]+)\s*(\(\(.*?\)\)|\(\[.*?\]\)|\[\(.*?\)\]|\[\[.*?\]\]|\[.*?\]|\(.*?\)|\{.*?\})?`)
+	linkRe      = regexp.MustCompile(`^(-{2,}>|-{3,}|-\.+->|-\.+-|={2,}>|={3,}|--\s+(.+?)\s+-{2,}>|-\.\s+(.+?)\s+\.+->|==\s+(.+?)\s+={2,}>)\s*(?:\|([^|]*)\|)?`)
+	brRe        = regexp.MustCompile(`(?i)<br\s*/?>`)
+)
+
+// Keywords of statements that don't affect the layout and are skipped.
+var ignoredKeywords = []string{"end", "classDef", "class", "style", "linkStyle", "click", "direction"}
+
+const (
+	// Gaps between the nodes of a rank and between ranks, in cells.
+	nodeGap     = 3
+	rankGapRows = 4
+	rankGapCols = 6
+)
+
+type flowNode struct {
+	id    string
+	lines []string
+	shape string
+	dummy bool
+
+	rank int
+	w, h int
+	x, y int
+}
+
+type flowEdge struct {
+	from, to string
+	label    string
+	style    lineStyle
+	arrow    bool
+}
+
+// flowLink joins two nodes of adjacent ranks, an edge spanning several ranks
+// is made of several links passing through dummy nodes.
+type flowLink struct {
+	a, b *flowNode
+	edge flowEdge
+	// first and last mark the ends of the edge.
+	first, last bool
+}
+
+type flowchart struct {
+	dir   string
+	nodes map[string]*flowNode
+	order []*flowNode
+	edges []flowEdge
+}
+
+// parseFlowchart parses the statements following the header, start being the
+// line number of the first one.
+func parseFlowchart(header string, lines []string, start int) (*flowchart, error) {
+	m := flowchartRe.FindStringSubmatch(header)
+	f := &flowchart{dir: "TD", nodes: map[string]*flowNode{}}
+	switch m[3] {
+	case "LR":
+		f.dir = "LR"
+	case "RL", "BT":
+		return nil, fmt.Errorf("flowchart direction %s is not supported, only TD and LR are", m[3])
+	}
+
+	for i, line := range lines {
+		for _, statement := range strings.Split(line, ";") {
+			if err := f.parseStatement(strings.TrimSpace(statement)); err != nil {
+				return nil, fmt.Errorf("line %d: %w", start+i, err)
+			}
+		}
+	}
+
+	if len(f.order) == 0 {
+		return nil, fmt.Errorf("flowchart has no nodes")
+	}
+	return f, nil
+}
+
+func (f *flowchart) parseStatement(s string) error {
+	if s == "" {
+		return nil
+	}
+	keyword, _, _ := strings.Cut(s, " ")
+	if keyword == "subgraph" {
+		return fmt.Errorf("subgraphs are not supported")
+	}
+	if slices.Contains(ignoredKeywords, keyword) {
+		return nil
+	}
+
+	prev, rest, err := f.parseNode(s)
+	if err != nil {
+		return err
+	}
+
+	for rest != "" {
+		if strings.HasPrefix(rest, "&") {
+			return fmt.Errorf("node groups joined with & are not supported")
+		}
+		m := linkRe.FindStringSubmatch(rest)
+		if m == nil {
+			return fmt.Errorf("expected a link, got %q", rest)
+		}
+		rest = strings.TrimSpace(rest[len(m[0]):])
+
+		e := flowEdge{
+			from:  prev.id,
+			label: strings.TrimSpace(m[2] + m[3] + m[4] + m[5]),
+			arrow: strings.HasSuffix(m[1], ">"),
+		}
+		switch {
+		case strings.HasPrefix(m[1], "-."):
+			e.style = dotted
+		case strings.HasPrefix(m[1], "=="):
+			e.style = thick
+		}
+
+		next, r, err := f.parseNode(rest)
+		if err != nil {
+			return err
+		}
+		e.to = next.id
+		if e.from == e.to {
+			return fmt.Errorf("links from a node to itself are not supported")
+		}
+		if slices.ContainsFunc(f.edges, func(o flowEdge) bool { return o.from == e.from && o.to == e.to }) {
+			return fmt.Errorf("links repeated between %s and %s are not supported", e.from, e.to)
+		}
+		f.edges = append(f.edges, e)
+
+		prev, rest = next, r
+	}
+
+	return nil
+}
+
+func (f *flowchart) parseNode(s string) (*flowNode, string, error) {
+	m := nodeRe.FindStringSubmatch(s)
+	if m == nil {
+		return nil, "", fmt.Errorf("expected a node, got %q", s)
+	}
+
+	n, ok := f.nodes[m[1]]
+	if !ok {
+		n = &flowNode{id: m[1], lines: []string{m[1]}, shape: "rect"}
+		f.nodes[m[1]] = n
+		f.order = append(f.order, n)
+	}
+
+	if shape := m[2]; shape != "" {
+		open := 1
+		switch {
+		case strings.HasPrefix(shape, "(("), strings.HasPrefix(shape, "(["), strings.HasPrefix(shape, "[("):
+			open = 2
+			n.shape = "round"
+		case strings.HasPrefix(shape, "[["):
+			open = 2
+			n.shape = "rect"
+		case strings.HasPrefix(shape, "("):
+			n.shape = "round"
+		case strings.HasPrefix(shape, "{"):
+			n.shape = "rhomb"
+		default:
+			n.shape = "rect"
+		}
+
+		text := strings.Trim(strings.TrimSpace(shape[open:len(shape)-open]), `"`)
+		n.lines = brRe.Split(text, -1)
+		for i := range n.lines {
+			n.lines[i] = strings.TrimSpace(n.lines[i])
+		}
+	}
+
+	return n, strings.TrimSpace(s[len(m[0]):]), nil
+}
+
+// rank assigns every node the length of the longest path leading to it,
+// ignoring the edges closing cycles. It returns those back edges.
+func (f *flowchart) rank() map[int]bool {
+	out := map[string][]int{}
+	for i, e := range f.edges {
+		out[e.from] = append(out[e.from], i)
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	back := map[int]bool{}
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = visiting
+		for _, i := range out[id] {
+			switch to := f.edges[i].to; state[to] {
+			case visiting:
+				back[i] = true
+			case 0:
+				visit(to)
+			}
+		}
+		state[id] = visited
+	}
+	for _, n := range f.order {
+		if state[n.id] == 0 {
+			visit(n.id)
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for i, e := range f.edges {
+			from, to := f.nodes[e.from], f.nodes[e.to]
+			if back[i] {
+				continue
+			}
+			if to.rank < from.rank+1 {
+				to.rank = from.rank + 1
+				changed = true
+			}
+		}
+	}
+
+	return back
+}
+
+// layers groups the nodes by rank, adding dummy nodes where edges cross
+// ranks, and orders every layer to reduce crossings. Back edges, closing
+// cycles, aren't part of the layers and are returned on their own to be
+// routed around the diagram.
+func (f *flowchart) layers() ([][]*flowNode, []flowLink, []flowEdge) {
+	back := f.rank()
+
+	var layers [][]*flowNode
+	add := func(n *flowNode) {
+		for len(layers) <= n.rank {
+			layers = append(layers, nil)
+		}
+		layers[n.rank] = append(layers[n.rank], n)
+	}
+	for _, n := range f.order {
+		add(n)
+	}
+
+	var (
+		links     []flowLink
+		backEdges []flowEdge
+	)
+	for i, e := range f.edges {
+		a, b := f.nodes[e.from], f.nodes[e.to]
+		if back[i] {
+			backEdges = append(backEdges, e)
+			continue
+		}
+
+		chain := []*flowNode{a}
+		for r := a.rank + 1; r < b.rank; r++ {
+			d := &flowNode{dummy: true, rank: r}
+			add(d)
+			chain = append(chain, d)
+		}
+		chain = append(chain, b)
+
+		for j := 0; j < len(chain)-1; j++ {
+			links = append(links, flowLink{
+				a:     chain[j],
+				b:     chain[j+1],
+				edge:  e,
+				first: j == 0,
+				last:  j == len(chain)-2,
+			})
+		}
+	}
+
+	// Barycenter ordering, sweeping down, up and down again.
+	index := func(n *flowNode) int {
+		return slices.Index(layers[n.rank], n)
+	}
+	sweep := func(r, from int) {
+		keys := map[*flowNode]float64{}
+		for i, n := range layers[r] {
+			sum, count := 0, 0
+			for _, l := range links {
+				switch {
+				case l.b == n && l.a.rank == from:
+					sum += index(l.a)
+					count++
+				case l.a == n && l.b.rank == from:
+					sum += index(l.b)
+					count++
+				}
+			}
+			keys[n] = float64(i)
+			if count > 0 {
+				keys[n] = float64(sum) / float64(count)
+			}
+		}
+		slices.SortStableFunc(layers[r], func(a, b *flowNode) int {
+			switch {
+			case keys[a] < keys[b]:
+				return -1
+			case keys[a] > keys[b]:
+				return 1
+			}
+			return 0
+		})
+	}
+	for r := 1; r < len(layers); r++ {
+		sweep(r, r-1)
+	}
+	for r := len(layers) - 2; r >= 0; r-- {
+		sweep(r, r+1)
+	}
+	for r := 1; r < len(layers); r++ {
+		sweep(r, r-1)
+	}
+
+	return layers, links, backEdges
+}
+
+func (n *flowNode) size() {
+	if n.dummy {
+		n.w, n.h = 1, 1
+		return
+	}
+	width := 0
+	for _, line := range n.lines {
+		width = max(width, runewidth.StringWidth(line))
+	}
+	n.w, n.h = width+4, len(n.lines)+2
+}
+
+// render lays the flowchart out in its direction and draws it.
+func (f *flowchart) render(dir string) *canvas {
+	layers, links, back := f.layers()
+	for _, layer := range layers {
+		for _, n := range layer {
+			n.size()
+		}
+	}
+
+	if dir == "LR" {
+		return f.renderLR(layers, links, back)
+	}
+	return f.renderTD(layers, links, back)
+}
+
+// backLanes counts, for every rank, the back edges leaving it and the back
+// edges entering it. Every back edge gets lanes of its own on both ends so
+// that it doesn't merge with the edges of its nodes.
+func (f *flowchart) backLanes(ranks int, back []flowEdge) (out, in []int, outLane, inLane []int) {
+	out, in = make([]int, ranks), make([]int, ranks)
+	for _, e := range back {
+		from, to := f.nodes[e.from].rank, f.nodes[e.to].rank
+		outLane = append(outLane, out[from])
+		inLane = append(inLane, in[to])
+		out[from]++
+		in[to]++
+	}
+	return out, in, outLane, inLane
+}
+
+func (f *flowchart) renderTD(layers [][]*flowNode, links []flowLink, back []flowEdge) *canvas {
+	labelWidth := 0
+	for _, l := range links {
+		labelWidth = max(labelWidth, runewidth.StringWidth(l.edge.label))
+	}
+	lanesOut, lanesIn, outLane, inLane := f.backLanes(len(layers), back)
+
+	rowTop := make([]int, len(layers))
+	rowHeight := make([]int, len(layers))
+	rowWidth := make([]int, len(layers))
+	width, height := 0, 0
+	if lanesIn[0] > 0 {
+		// Room above the first rank for back edges coming into it.
+		height = lanesIn[0] + 1
+	}
+	for r, layer := range layers {
+		rowHeight[r] = 1
+		for i, n := range layer {
+			if !n.dummy {
+				rowHeight[r] = max(rowHeight[r], n.h)
+			}
+			if i > 0 {
+				rowWidth[r] += nodeGap
+			}
+			rowWidth[r] += n.w
+		}
+		rowTop[r] = height
+		height += rowHeight[r] + rankGapRows + lanesOut[r]
+		if r+1 < len(layers) {
+			height += lanesIn[r+1]
+		}
+		width = max(width, rowWidth[r])
+	}
+	last := len(layers) - 1
+	height = rowTop[last] + rowHeight[last]
+	if lanesOut[last] > 0 {
+		height += lanesOut[last] + 1
+	}
+
+	// Back edges go up on the right of the diagram, each in a column of its
+	// own followed by its label.
+	laneX := make([]int, len(back))
+	right := width + labelWidth + 2
+	for i, e := range back {
+		laneX[i] = right
+		right += 2
+		if e.label != "" {
+			right += runewidth.StringWidth(e.label) + 1
+		}
+	}
+
+	c := newCanvas(right, height)
+	for r, layer := range layers {
+		x := (width - rowWidth[r]) / 2
+		for _, n := range layer {
+			n.x, n.y = x, rowTop[r]
+			if n.dummy {
+				n.h = rowHeight[r]
+			}
+			x += n.w + nodeGap
+		}
+	}
+
+	for _, n := range f.order {
+		c.box(n.x, n.y, n.w, n.h, n.shape, n.lines)
+	}
+
+	for _, l := range links {
+		ax, bx := l.a.x+l.a.w/2, l.b.x+l.b.w/2
+		r := l.a.rank
+		gap := rowTop[r] + rowHeight[r]
+		ym := gap + 1 + lanesOut[r]
+		sy := l.a.y + l.a.h
+		ty := rowTop[r+1]
+
+		if l.a.dummy {
+			c.vline(ax, l.a.y, sy, l.edge.style)
+		}
+		c.vline(ax, sy, ym, l.edge.style)
+		c.hline(ax, bx, ym, l.edge.style)
+		c.vline(bx, ym, ty-1, l.edge.style)
+		if l.b.dummy {
+			c.vline(bx, ty-1, ty+l.b.h, l.edge.style)
+		}
+
+		if l.edge.arrow && l.last {
+			c.set(bx, ty-1, '▼', edge)
+		}
+		if l.first && l.edge.label != "" {
+			c.text(bx+2, ym+1, l.edge.label, label)
+		}
+	}
+
+	for i, e := range back {
+		a, b := f.nodes[e.from], f.nodes[e.to]
+		ax, bx := a.x+a.w/2, b.x+b.w/2
+		ys := rowTop[a.rank] + rowHeight[a.rank] + 1 + outLane[i]
+		ty := rowTop[b.rank]
+		yin := ty - 2 - inLane[i]
+
+		c.vline(ax, a.y+a.h, ys, e.style)
+		c.hline(ax, laneX[i], ys, e.style)
+		c.vline(laneX[i], yin, ys, e.style)
+		c.hline(bx, laneX[i], yin, e.style)
+		c.vline(bx, yin, ty-1, e.style)
+		if e.arrow {
+			c.set(bx, ty-1, '▼', edge)
+		}
+		if e.label != "" {
+			c.text(laneX[i]+2, (ys+yin)/2, e.label, label)
+		}
+	}
+
+	return c
+}
+
+func (f *flowchart) renderLR(layers [][]*flowNode, links []flowLink, back []flowEdge) *canvas {
+	lanesOut, lanesIn, outLane, inLane := f.backLanes(len(layers), back)
+
+	colLeft := make([]int, len(layers))
+	colWidth := make([]int, len(layers))
+	colHeight := make([]int, len(layers))
+	gaps := make([]int, len(layers))
+	for _, l := range links {
+		if l.first {
+			gaps[l.a.rank] = max(gaps[l.a.rank], runewidth.StringWidth(l.edge.label))
+		}
+	}
+
+	width, height := 0, 0
+	if lanesIn[0] > 0 {
+		// Room left of the first rank for back edges coming into it.
+		width = lanesIn[0] + 1
+	}
+	for r, layer := range layers {
+		colWidth[r] = 1
+		for i, n := range layer {
+			if !n.dummy {
+				colWidth[r] = max(colWidth[r], n.w)
+			}
+			if i > 0 {
+				colHeight[r]++
+			}
+			colHeight[r] += n.h
+		}
+		colLeft[r] = width
+		width += colWidth[r] + max(rankGapCols, gaps[r]+6) + lanesOut[r]
+		if r+1 < len(layers) {
+			width += lanesIn[r+1]
+		}
+		height = max(height, colHeight[r])
+	}
+
+	// Back edges go back below the diagram, each on a row of its own
+	// followed by its label.
+	laneY := make([]int, len(back))
+	bottom := height + 1
+	for i, e := range back {
+		laneY[i] = bottom
+		bottom++
+		if e.label != "" {
+			bottom++
+		}
+	}
+	if len(back) == 0 {
+		bottom = height
+	}
+
+	c := newCanvas(width, bottom)
+	for r, layer := range layers {
+		y := (height - colHeight[r]) / 2
+		for _, n := range layer {
+			n.x, n.y = colLeft[r]+(colWidth[r]-n.w)/2, y
+			if n.dummy {
+				n.x, n.w = colLeft[r], colWidth[r]
+			}
+			y += n.h + 1
+		}
+	}
+
+	for _, n := range f.order {
+		c.box(n.x, n.y, n.w, n.h, n.shape, n.lines)
+	}
+
+	for _, l := range links {
+		ay, by := l.a.y+l.a.h/2, l.b.y+l.b.h/2
+		r := l.a.rank
+		xm := colLeft[r] + colWidth[r] + 1 + lanesOut[r]
+		sx := l.a.x + l.a.w
+		tx := l.b.x
+
+		if l.a.dummy {
+			c.hline(l.a.x, sx, ay, l.edge.style)
+		}
+		c.hline(sx, xm, ay, l.edge.style)
+		c.vline(xm, ay, by, l.edge.style)
+		c.hline(xm, tx-1, by, l.edge.style)
+		if l.b.dummy {
+			c.hline(tx-1, tx+l.b.w, by, l.edge.style)
+		}
+
+		if l.edge.arrow && l.last {
+			c.set(tx-1, by, '▶', edge)
+		}
+		if l.first && l.edge.label != "" {
+			c.text(xm+2, by-1, l.edge.label, label)
+		}
+	}
+
+	for i, e := range back {
+		a, b := f.nodes[e.from], f.nodes[e.to]
+		ay, by := a.y+a.h/2, b.y+b.h/2
+		xs := colLeft[a.rank] + colWidth[a.rank] + 1 + outLane[i]
+		xin := colLeft[b.rank] - 2 - inLane[i]
+
+		c.hline(a.x+a.w, xs, ay, e.style)
+		c.vline(xs, ay, laneY[i], e.style)
+		c.hline(xin, xs, laneY[i], e.style)
+		c.vline(xin, by, laneY[i], e.style)
+		c.hline(xin, b.x-1, by, e.style)
+		if e.arrow {
+			c.set(b.x-1, by, '▶', edge)
+		}
+		if e.label != "" {
+			c.text(xin+2, laneY[i]+1, e.label, label)
+		}
+	}
+
+	return c
+}
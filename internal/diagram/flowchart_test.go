@@ -0,0 +1,132 @@
+package diagram
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFlowchartLayout(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		want string
+	}{
+		{
+			name: "fan out",
+			give: "graph TD; A-->B; A-->C",
+			want: `
+    ┌───┐
+    │ A │
+    └───┘
+      │
+  ┌───┴───┐
+  │       │
+  ▼       ▼
+┌───┐   ┌───┐
+│ B │   │ C │
+└───┘   └───┘`,
+		},
+		{
+			name: "labels",
+			give: "graph LR\nA-- go -->B-->C",
+			want: `
+┌───┐   go   ┌───┐      ┌───┐
+│ A │───────▶│ B │─────▶│ C │
+└───┘        └───┘      └───┘`,
+		},
+		{
+			name: "cycle LR",
+			give: "graph LR; A-->B; B-->C; A-->C; C-->D; B-->D; D-->A",
+			want: `
+             ┌───┐ ┌──────────┐
+  ┌───┐ ┌───▶│ B │─┤          │    ┌───┐
+┌▶│ A │─┤    └───┘ │    ┌───┐ ├───▶│ D │─┐
+│ └───┘ │          ├───▶│ C │─┘    └───┘ │
+│       └──────────┘    └───┘            │
+│                                        │
+└────────────────────────────────────────┘`,
+		},
+		{
+			name: "cycle TD",
+			give: "graph TD; A-->B; B-->C; A-->C; C-->D; B-->D; D-->A",
+			want: `
+    ┌──────┐
+    ▼      │
+  ┌───┐    │
+  │ A │    │
+  └───┘    │
+    │      │
+  ┌─┴───┐  │
+  │     │  │
+  ▼     │  │
+┌───┐   │  │
+│ B │   │  │
+└───┘   │  │
+  │     │  │
+┌─┴───┬─┘  │
+│     │    │
+│     ▼    │
+│   ┌───┐  │
+│   │ C │  │
+│   └───┘  │
+│     │    │
+└───┬─┘    │
+    │      │
+    ▼      │
+  ┌───┐    │
+  │ D │    │
+  └───┘    │
+    │      │
+    └──────┘`,
+		},
+		{
+			name: "labeled back edge",
+			give: "graph LR\nA[Write] --> B{Good?}\nB -.->|No| A",
+			want: `
+  ┌───────┐      ╔═══════╗
+┌▶│ Write │─────▶║ Good? ║┄┐
+┆ └───────┘      ╚═══════╝ ┆
+┆                          ┆
+└┄┄┄┄┄┄┄┄┄┄┄┄┄┄┄┄┄┄┄┄┄┄┄┄┄┄┘
+  No`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.give, 200, Style{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := strings.TrimPrefix(tt.want, "\n")
+			if strings.Join(got, "\n") != want {
+				t.Errorf("Expected:\n\n%s\n\nActual Output:\n\n%s", want, strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+func TestFlowchartErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		want string
+	}{
+		{"subgraph", "graph TD\nsubgraph one\nA-->B\nend", "line 2: subgraphs are not supported"},
+		{"node group", "graph TD\nA-->B & C", "line 2: node groups joined with & are not supported"},
+		{"bad link", "graph TD\nA->B", `line 2: expected a link, got "->B"`},
+		{"right to left", "graph RL\nA-->B", "flowchart direction RL is not supported, only TD and LR are"},
+		{"bottom to top", "flowchart BT; A-->B", "flowchart direction BT is not supported, only TD and LR are"},
+		{"self loop", "graph TD\nA-->B-->B", "line 2: links from a node to itself are not supported"},
+		{"repeated link", "graph TD\nA-- yes -->B\nA-- no -->B", "line 3: links repeated between A and B are not supported"},
+		{"no nodes", "graph TD\n%% nothing", "flowchart has no nodes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Render(tt.give, 200, Style{}); err == nil || err.Error() != tt.want {
+				t.Errorf("Expected error %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
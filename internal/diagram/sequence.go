@@ -0,0 +1,218 @@
+package diagram
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+var (
+	participantRe = regexp.MustCompile(`^(participant|actor)\s+([\p{L}\p{N}_]+)(?:\s+as\s+(.+))?$`)
+	messageRe     = regexp.MustCompile(`^([\p{L}\p{N}_]+)\s*(-->>|->>|-->|->)\s*([\p{L}\p{N}_]+)\s*:\s*(.*)$`)
+)
+
+// Keywords of sequence diagram statements that are not supported, with what
+// they declare.
+var unsupportedSequenceKeywords = map[string]string{
+	"autonumber": "numbered messages",
+	"activate":   "activations",
+	"deactivate": "activations",
+	"Note":       "notes",
+	"note":       "notes",
+	"loop":       "loop blocks",
+	"alt":        "alt blocks",
+	"opt":        "opt blocks",
+	"par":        "par blocks",
+	"critical":   "critical blocks",
+	"break":      "break blocks",
+	"rect":       "rect blocks",
+	"box":        "participant boxes",
+}
+
+type participant struct {
+	id, label string
+	// x is the column of the lifeline.
+	x int
+}
+
+type message struct {
+	from, to *participant
+	text     string
+	style    lineStyle
+	arrow    bool
+}
+
+type sequence struct {
+	participants []*participant
+	messages     []message
+}
+
+// parseSequence parses the statements following the header, start being the
+// line number of the first one.
+func parseSequence(lines []string, start int) (*sequence, error) {
+	s := &sequence{}
+	for i, line := range lines {
+		if err := s.parseStatement(line); err != nil {
+			return nil, fmt.Errorf("line %d: %w", start+i, err)
+		}
+	}
+
+	if len(s.participants) == 0 {
+		return nil, fmt.Errorf("sequence diagram has no participants")
+	}
+	return s, nil
+}
+
+func (s *sequence) parseStatement(line string) error {
+	if line == "" {
+		return nil
+	}
+	keyword, _, _ := strings.Cut(line, " ")
+	if what, ok := unsupportedSequenceKeywords[keyword]; ok {
+		return fmt.Errorf("%s are not supported", what)
+	}
+
+	if m := participantRe.FindStringSubmatch(line); m != nil {
+		p := s.participant(m[2])
+		if m[3] != "" {
+			p.label = strings.TrimSpace(m[3])
+		}
+		return nil
+	}
+
+	m := messageRe.FindStringSubmatch(line)
+	if m == nil {
+		return fmt.Errorf("expected a participant or a message, got %q", line)
+	}
+	msg := message{
+		from:  s.participant(m[1]),
+		to:    s.participant(m[3]),
+		text:  strings.TrimSpace(m[4]),
+		arrow: strings.HasSuffix(m[2], ">>"),
+	}
+	if strings.HasPrefix(m[2], "--") {
+		msg.style = dotted
+	}
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func (s *sequence) participant(id string) *participant {
+	for _, p := range s.participants {
+		if p.id == id {
+			return p
+		}
+	}
+	p := &participant{id: id, label: id}
+	s.participants = append(s.participants, p)
+	return p
+}
+
+func (s *sequence) render() *canvas {
+	index := func(p *participant) int {
+		return slices.Index(s.participants, p)
+	}
+
+	// Gaps between adjacent lifelines, wide enough for the boxes and for the
+	// labels of the messages between them.
+	gaps := make([]int, len(s.participants))
+	for i := 1; i < len(s.participants); i++ {
+		gaps[i] = boxWidth(s.participants[i-1])/2 + boxWidth(s.participants[i])/2 + 3
+	}
+	loop := 0
+	for _, m := range s.messages {
+		a, b := index(m.from), index(m.to)
+		if a > b {
+			a, b = b, a
+		}
+		need := runewidth.StringWidth(m.text) + 4
+		if a == b {
+			if b+1 < len(gaps) {
+				gaps[b+1] = max(gaps[b+1], need+4)
+			} else {
+				loop = max(loop, need+2)
+			}
+			continue
+		}
+		if have := sum(gaps[a+1 : b+1]); have < need {
+			gaps[b] += need - have
+		}
+	}
+
+	x := boxWidth(s.participants[0]) / 2
+	for i, p := range s.participants {
+		x += gaps[i]
+		p.x = x
+	}
+
+	height := 3
+	for _, m := range s.messages {
+		height += 3
+		if m.from == m.to {
+			height++
+		}
+	}
+	height += 4
+
+	last := s.participants[len(s.participants)-1]
+	c := newCanvas(last.x+boxWidth(last)/2+1+loop, height)
+
+	y := 4
+	for _, m := range s.messages {
+		w := runewidth.StringWidth(m.text)
+		a, b := m.from.x, m.to.x
+		switch {
+		case a == b:
+			c.text(a+2, y, m.text, label)
+			c.hline(a+1, a+4, y+1, m.style)
+			c.vline(a+4, y+1, y+2, m.style)
+			c.hline(a+1, a+4, y+2, m.style)
+			if m.arrow {
+				c.set(a+1, y+2, '◀', edge)
+			}
+			y += 4
+			continue
+		case a < b:
+			c.hline(a+1, b-1, y+1, m.style)
+			if m.arrow {
+				c.set(b-1, y+1, '▶', edge)
+			}
+		default:
+			c.hline(b+1, a-1, y+1, m.style)
+			if m.arrow {
+				c.set(b+1, y+1, '◀', edge)
+			}
+		}
+		left := min(a, b)
+		c.text(left+(max(a, b)-left-w)/2+1, y, m.text, label)
+		y += 3
+	}
+
+	for _, p := range s.participants {
+		w := boxWidth(p)
+		c.box(p.x-w/2, 0, w, 3, "rect", []string{p.label})
+		c.box(p.x-w/2, height-3, w, 3, "rect", []string{p.label})
+		for y := 3; y < height-3; y++ {
+			if c.cells[y][p.x].kind == empty {
+				c.cells[y][p.x] = cell{r: '┆', kind: edge}
+			}
+		}
+	}
+
+	return c
+}
+
+func boxWidth(p *participant) int {
+	return runewidth.StringWidth(p.label) + 4
+}
+
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
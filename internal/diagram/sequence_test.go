@@ -0,0 +1,110 @@
+package diagram
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSequenceLayout(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		want string
+	}{
+		{
+			name: "messages",
+			give: "sequenceDiagram\nAlice->>Bob: Hi\nBob-->>Alice: Hello",
+			want: `
+┌───────┐  ┌─────┐
+│ Alice │  │ Bob │
+└───────┘  └─────┘
+    ┆         ┆
+    ┆    Hi   ┆
+    ┆────────▶┆
+    ┆         ┆
+    ┆  Hello  ┆
+    ┆◀┄┄┄┄┄┄┄┄┆
+    ┆         ┆
+┌───────┐  ┌─────┐
+│ Alice │  │ Bob │
+└───────┘  └─────┘`,
+		},
+		{
+			name: "participants and self messages",
+			give: "sequenceDiagram\nparticipant B as Backend\nactor U\nU->B: get\nB->B: think\nB-->U: data",
+			want: `
+┌─────────┐     ┌───┐
+│ Backend │     │ U │
+└─────────┘     └───┘
+     ┆            ┆
+     ┆     get    ┆
+     ┆────────────┆
+     ┆            ┆
+     ┆ think      ┆
+     ┆───┐        ┆
+     ┆───┘        ┆
+     ┆            ┆
+     ┆    data    ┆
+     ┆┄┄┄┄┄┄┄┄┄┄┄┄┆
+     ┆            ┆
+┌─────────┐     ┌───┐
+│ Backend │     │ U │
+└─────────┘     └───┘`,
+		},
+		{
+			name: "long message across a lifeline",
+			give: "sequenceDiagram\nA->>C: a very long message\nB->>A: x",
+			want: `
+┌───┐                  ┌───┐  ┌───┐
+│ A │                  │ C │  │ B │
+└───┘                  └───┘  └───┘
+  ┆                      ┆      ┆
+  ┆  a very long message ┆      ┆
+  ┆─────────────────────▶┆      ┆
+  ┆                      ┆      ┆
+  ┆              x       ┆      ┆
+  ┆◀────────────────────────────┆
+  ┆                      ┆      ┆
+┌───┐                  ┌───┐  ┌───┐
+│ A │                  │ C │  │ B │
+└───┘                  └───┘  └───┘`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.give, 200, Style{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := strings.TrimPrefix(tt.want, "\n")
+			if strings.Join(got, "\n") != want {
+				t.Errorf("Expected:\n\n%s\n\nActual Output:\n\n%s", want, strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+func TestSequenceErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		want string
+	}{
+		{"note", "sequenceDiagram\nA->>B: hi\nNote right of B: hm", "line 3: notes are not supported"},
+		{"loop", "sequenceDiagram\nloop every minute\nA->>B: hi\nend", "line 2: loop blocks are not supported"},
+		{"alt", "sequenceDiagram\nalt ok\nA->>B: hi\nelse\nA->>B: bye\nend", "line 2: alt blocks are not supported"},
+		{"activation", "sequenceDiagram\nA->>B: hi\nactivate B", "line 3: activations are not supported"},
+		{"autonumber", "sequenceDiagram\nautonumber\nA->>B: hi", "line 2: numbered messages are not supported"},
+		{"bad message", "sequenceDiagram\nA=>B: hi", `line 2: expected a participant or a message, got "A=>B: hi"`},
+		{"no participants", "sequenceDiagram\n%% nothing", "sequence diagram has no participants"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Render(tt.give, 200, Style{}); err == nil || err.Error() != tt.want {
+				t.Errorf("Expected error %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
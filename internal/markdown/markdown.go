@@ -0,0 +1,136 @@
+// Package markdown finds the code in markdown sources, fenced code blocks and
+// code spans, which kyma leaves untouched when it rewrites slides.
+package markdown
+
+import "strings"
+
+// Line tells how a line relates to fenced code blocks.
+type Line int
+
+const (
+	// Text is a line outside of fenced code blocks.
+	Text Line = iota
+	// Open is the opening fence of a code block.
+	Open
+	// Code is a line inside of a code block.
+	Code
+	// Close is the closing fence of a code block.
+	Close
+)
+
+// Fences tracks the fenced code blocks of a document read line by line.
+type Fences struct {
+	marker string
+	info   string
+}
+
+// Next returns how line relates to the fenced code blocks of the document,
+// given the lines before it.
+func (f *Fences) Next(line string) Line {
+	if f.marker == "" {
+		marker, info, ok := Fence(line)
+		if !ok {
+			return Text
+		}
+		f.marker, f.info = marker, info
+		return Open
+	}
+
+	if marker, info, ok := Fence(line); ok && info == "" &&
+		marker[0] == f.marker[0] && len(marker) >= len(f.marker) {
+		f.marker, f.info = "", ""
+		return Close
+	}
+	return Code
+}
+
+// Inside reports whether the last line read is an opening fence or inside of
+// a code block, which is the case at the end of a block that is never
+// closed.
+func (f *Fences) Inside() bool {
+	return f.marker != ""
+}
+
+// Info returns the info string of the code block being read.
+func (f *Fences) Info() string {
+	return f.info
+}
+
+// Fence reports whether line is a code fence, a run of at least three
+// backticks or tildes indented by up to three spaces, and returns the run
+// along with the info string following it.
+func Fence(line string) (marker, info string, ok bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return "", "", false
+	}
+
+	for _, c := range []string{"`", "~"} {
+		if !strings.HasPrefix(trimmed, c+c+c) {
+			continue
+		}
+
+		rest := strings.TrimLeft(trimmed, c)
+		info = strings.TrimSpace(rest)
+		if c == "`" && strings.Contains(info, "`") {
+			// Inline code spanning the line, not a fence.
+			return "", "", false
+		}
+		return trimmed[:len(trimmed)-len(rest)], info, true
+	}
+
+	return "", "", false
+}
+
+// OutsideCodeSpans applies f to the parts of line outside of code spans,
+// which are delimited by runs of backticks of the same length. Backticks
+// without a matching run are part of the text.
+func OutsideCodeSpans(line string, f func(string) string) string {
+	var (
+		b    strings.Builder
+		text int
+	)
+	for i := 0; i < len(line); {
+		if line[i] != '`' {
+			i++
+			continue
+		}
+
+		n := backticks(line[i:])
+		end := closingBackticks(line[i+n:], n)
+		if end < 0 {
+			i += n
+			continue
+		}
+		end += i + n
+
+		b.WriteString(f(line[text:i]))
+		b.WriteString(line[i : end+n])
+		i = end + n
+		text = i
+	}
+	b.WriteString(f(line[text:]))
+	return b.String()
+}
+
+// backticks returns the length of the run of backticks s starts with.
+func backticks(s string) int {
+	return len(s) - len(strings.TrimLeft(s, "`"))
+}
+
+// closingBackticks returns the index of the first run of exactly n backticks
+// in s, or -1.
+func closingBackticks(s string, n int) int {
+	for i := 0; i < len(s); {
+		if s[i] != '`' {
+			i++
+			continue
+		}
+		run := backticks(s[i:])
+		if run == n {
+			return i
+		}
+		i += run
+	}
+	return -1
+}
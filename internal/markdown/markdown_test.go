@@ -0,0 +1,125 @@
+package markdown
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFences(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		want []Line
+		info []string
+	}{
+		{
+			name: "backticks",
+			give: "a\n```go\ncode\n```\nb",
+			want: []Line{Text, Open, Code, Close, Text},
+			info: []string{"", "go", "go", "", ""},
+		},
+		{
+			name: "tildes",
+			give: "~~~\ncode\n~~~",
+			want: []Line{Open, Code, Close},
+		},
+		{
+			name: "indented",
+			give: "   ```\n  code\n ```",
+			want: []Line{Open, Code, Close},
+		},
+		{
+			name: "indented too far",
+			give: "    ```\ncode\n    ```",
+			want: []Line{Text, Text, Text},
+		},
+		{
+			name: "shorter fence inside",
+			give: "````md\n```\ncode\n```\n````",
+			want: []Line{Open, Code, Code, Code, Close},
+			info: []string{"md", "md", "md", "md", ""},
+		},
+		{
+			name: "longer closing fence",
+			give: "```\ncode\n`````",
+			want: []Line{Open, Code, Close},
+		},
+		{
+			name: "other fence character inside",
+			give: "```\n~~~\n```",
+			want: []Line{Open, Code, Close},
+		},
+		{
+			name: "closing fence with info",
+			give: "```\n```go\n```",
+			want: []Line{Open, Code, Close},
+		},
+		{
+			name: "inline code",
+			give: "```a``` b\n```",
+			want: []Line{Text, Open},
+		},
+		{
+			name: "windows line endings",
+			give: "```go\r\ncode\r\n```\r\ntext",
+			want: []Line{Open, Code, Close, Text},
+		},
+		{
+			name: "never closed",
+			give: "```\ncode",
+			want: []Line{Open, Code},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				f    Fences
+				got  []Line
+				info []string
+			)
+			for _, line := range strings.Split(tt.give, "\n") {
+				got = append(got, f.Next(line))
+				info = append(info, f.Info())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+			if tt.info != nil && !reflect.DeepEqual(info, tt.info) {
+				t.Errorf("Expected info %q, got %q", tt.info, info)
+			}
+			if inside := got[len(got)-1] == Open || got[len(got)-1] == Code; f.Inside() != inside {
+				t.Errorf("Expected Inside to be %t", inside)
+			}
+		})
+	}
+}
+
+func TestOutsideCodeSpans(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		want string
+	}{
+		{"no code", "a b", "[a b]"},
+		{"code span", "a `b` c", "[a ]`b`[ c]"},
+		{"code span only", "`b`", "[]`b`[]"},
+		{"double backticks", "a ``b ` c`` d", "[a ]``b ` c``[ d]"},
+		{"different lengths", "a ``b` c", "[a ``b` c]"},
+		{"unmatched then matched", "a ` b ``c`` d", "[a ` b ]``c``[ d]"},
+		{"longer run inside", "`a ``` b` c", "[]`a ``` b`[ c]"},
+		{"unclosed", "a `b", "[a `b]"},
+		{"double run inside", "`a``b`", "[]`a``b`[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OutsideCodeSpans(tt.give, func(s string) string { return "[" + s + "]" })
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
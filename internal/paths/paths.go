@@ -0,0 +1,31 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Resolve resolves path relative to the base directory, expanding a leading
+// ~ to the home directory of the current user. Absolute paths are returned
+// cleaned but otherwise untouched.
+func Resolve(base, path string) string {
+	path = Expand(path)
+	if filepath.IsAbs(path) || base == "" {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(base, path)
+}
+
+// Expand expands a leading ~ to the home directory of the current user.
+func Expand(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
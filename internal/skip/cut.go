@@ -0,0 +1,146 @@
+package skip
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/muesli/reflow/ansi"
+	"github.com/rivo/uniseg"
+)
+
+const (
+	sgrReset       = "\x1b[0m"
+	hyperlinkReset = "\x1b]8;;\x1b\\"
+)
+
+// Cut returns the columns of a line of text from start up to end in a single
+// pass. The styles and hyperlink active at start are emitted before the slice
+// and reset after it, so it can be drawn anywhere on its own. Wide characters
+// crossing either edge are replaced by spaces to preserve alignment.
+func Cut(s string, start, end int) string {
+	if start < 0 {
+		start = 0
+	}
+	if end <= start {
+		return ""
+	}
+
+	var (
+		b        strings.Builder
+		state    seqState
+		seqStart int
+		sgr      string
+		link     string
+		col      int
+		started  bool
+	)
+	// begin emits the state active at start before the first column.
+	begin := func() {
+		if !started {
+			started = true
+			b.WriteString(sgr)
+			b.WriteString(link)
+		}
+	}
+
+	for i := 0; i < len(s) && col < end; {
+		if state != seqNone || s[i] == ansi.Marker {
+			if state == seqNone {
+				seqStart = i
+			}
+			r, size := utf8.DecodeRuneInString(s[i:])
+			state = state.next(r)
+			i += size
+			if state != seqNone {
+				continue
+			}
+
+			// Sequences up to the first column of the slice are folded into
+			// the state emitted by begin.
+			sq := s[seqStart:i]
+			if started {
+				b.WriteString(sq)
+			}
+			switch {
+			case isSGR(sq):
+				sgr = applySGR(sgr, sq)
+			case strings.HasPrefix(sq, "\x1b]8;"):
+				link = applyHyperlink(sq)
+			}
+			continue
+		}
+
+		text := s[i:]
+		if j := strings.IndexByte(text, ansi.Marker); j >= 0 {
+			text = text[:j]
+		}
+		i += len(text)
+
+		gstate := -1
+		for len(text) > 0 && col < end {
+			var (
+				cluster string
+				w       int
+			)
+			cluster, text, w, gstate = uniseg.FirstGraphemeClusterInString(text, gstate)
+
+			switch {
+			case col+w <= start:
+			case col < start || col+w > end:
+				// Wide character crossing an edge.
+				begin()
+				b.WriteString(strings.Repeat(" ", min(col+w, end)-max(col, start)))
+			default:
+				begin()
+				b.WriteString(cluster)
+			}
+			col += w
+		}
+	}
+
+	if started {
+		if sgr != "" {
+			b.WriteString(sgrReset)
+		}
+		if link != "" {
+			b.WriteString(hyperlinkReset)
+		}
+	}
+	return b.String()
+}
+
+// Slice cuts every line of s from start up to end.
+func Slice(s string, start, end int) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = Cut(line, start, end)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isSGR(seq string) bool {
+	return strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m")
+}
+
+// applySGR returns the SGR sequences active after seq, given the ones active
+// before it. Sequences starting with a reset replace the ones before.
+func applySGR(active, seq string) string {
+	params := seq[2 : len(seq)-1]
+	switch {
+	case params == "" || params == "0":
+		return ""
+	case strings.HasPrefix(params, "0;") || strings.HasPrefix(params, ";"):
+		return seq
+	}
+	return active + seq
+}
+
+// applyHyperlink returns the hyperlink sequence active after the OSC 8
+// sequence seq, empty when it closes the link.
+func applyHyperlink(seq string) string {
+	body := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(seq, "\x1b]8;"), "\a"), "\x1b\\")
+	if _, uri, _ := strings.Cut(body, ";"); uri == "" {
+		return ""
+	}
+	return seq
+}
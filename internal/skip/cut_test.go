@@ -0,0 +1,172 @@
+package skip_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/museslabs/kyma/internal/skip"
+)
+
+func TestCut(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		give       string
+		start, end int
+		want       string
+	}{
+		{
+			name:  "plain",
+			give:  "foobar",
+			start: 2,
+			end:   4,
+			want:  "ob",
+		},
+		{
+			name:  "from the start",
+			give:  "foobar",
+			start: 0,
+			end:   3,
+			want:  "foo",
+		},
+		{
+			name:  "past the end",
+			give:  "foo",
+			start: 1,
+			end:   10,
+			want:  "oo",
+		},
+		{
+			name:  "empty range",
+			give:  "foo",
+			start: 2,
+			end:   2,
+			want:  "",
+		},
+		{
+			name:  "start past the end",
+			give:  "foo",
+			start: 5,
+			end:   8,
+			want:  "",
+		},
+		{
+			name:  "negative start",
+			give:  "foo",
+			start: -2,
+			end:   2,
+			want:  "fo",
+		},
+		{
+			name:  "style active at start is re-emitted",
+			give:  "\x1b[1mfoo\x1b[31mbar\x1b[0m",
+			start: 4,
+			end:   6,
+			want:  "\x1b[1m\x1b[31mar\x1b[0m",
+		},
+		{
+			name:  "style reset before start is dropped",
+			give:  "\x1b[1mfoo\x1b[0mbar",
+			start: 3,
+			end:   6,
+			want:  "bar",
+		},
+		{
+			name:  "style reset and set in one sequence",
+			give:  "\x1b[1mfoo\x1b[0;32mbar",
+			start: 4,
+			end:   6,
+			want:  "\x1b[0;32mar\x1b[0m",
+		},
+		{
+			name:  "style inside the slice is reset at the end",
+			give:  "foo\x1b[7mbar",
+			start: 2,
+			end:   4,
+			want:  "o\x1b[7mb\x1b[0m",
+		},
+		{
+			name:  "unstyled slice gets no reset",
+			give:  "foo\x1b[7mbar",
+			start: 0,
+			end:   2,
+			want:  "fo",
+		},
+		{
+			name:  "hyperlink active at start is reopened and closed",
+			give:  "\x1b]8;;https://example.com\afoobar\x1b]8;;\a!",
+			start: 3,
+			end:   5,
+			want:  "\x1b]8;;https://example.com\aba\x1b]8;;\x1b\\",
+		},
+		{
+			name:  "closed hyperlink isn't reopened",
+			give:  "\x1b]8;;https://example.com\x1b\\foo\x1b]8;;\x1b\\bar",
+			start: 3,
+			end:   6,
+			want:  "bar",
+		},
+		{
+			name:  "wide character crossing the start",
+			give:  "a你好",
+			start: 2,
+			end:   5,
+			want:  " 好",
+		},
+		{
+			name:  "wide character crossing the end",
+			give:  "你好",
+			start: 0,
+			end:   3,
+			want:  "你 ",
+		},
+		{
+			name:  "emoji zwj sequence",
+			give:  "a👩‍💻b",
+			start: 1,
+			end:   3,
+			want:  "👩‍💻",
+		},
+		{
+			name:  "combining marks",
+			give:  "étude",
+			start: 0,
+			end:   2,
+			want:  "ét",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := skip.Cut(tt.give, tt.start, tt.end)
+			if got != tt.want {
+				t.Errorf("Expected:\n\n`%q`\n\nActual Output:\n\n`%q`", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSlice(t *testing.T) {
+	got := skip.Slice("foobar\n\x1b[1mbazqux\n", 2, 4)
+	want := "ob\n\x1b[1mzq\x1b[0m\n"
+	if got != want {
+		t.Errorf("Expected:\n\n`%q`\n\nActual Output:\n\n`%q`", want, got)
+	}
+}
+
+var benchLine = strings.Repeat("\x1B[38;2;249;38;114mhello你好\x1B[0m \x1B]8;;https://example.com\alink\x1B]8;;\a ", 8)
+
+func BenchmarkCut(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		skip.Cut(benchLine, 20, 80)
+	}
+}
+
+// BenchmarkSkipTruncate slices the same columns as BenchmarkCut by skipping
+// and truncating, the way transitions used to.
+func BenchmarkSkipTruncate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ansi.Truncate(skip.String(benchLine, 20), 60, "")
+	}
+}
@@ -5,9 +5,11 @@ package skip
 import (
 	"bytes"
 	"io"
+	"strings"
+	"unicode/utf8"
 
-	"github.com/mattn/go-runewidth"
 	"github.com/muesli/reflow/ansi"
+	"github.com/rivo/uniseg"
 )
 
 // Writer drops some number of leading columns from a line of text, while
@@ -16,9 +18,62 @@ type Writer struct {
 	width  uint
 	prefix string
 
-	ansiWriter ansi.Writer
-	buf        bytes.Buffer
-	ansi       bool
+	forward io.Writer
+	buf     bytes.Buffer
+	seq     seqState
+}
+
+// seqState tracks the escape sequence being written, which can span writes.
+type seqState int
+
+const (
+	seqNone seqState = iota
+	// seqEsc follows an escape character.
+	seqEsc
+	// seqCSI is a control sequence, ended by a final byte.
+	seqCSI
+	// seqString is a control string: an operating system command like a
+	// hyperlink, a device control string, or one of the rarer SOS, PM and
+	// APC. It is ended by a BEL or a string terminator.
+	seqString
+	// seqStringEsc follows an escape character in a control string, a
+	// backslash after it making the string terminator.
+	seqStringEsc
+)
+
+// next returns the state after r, which is part of a sequence unless the
+// state is seqNone.
+func (s seqState) next(r rune) seqState {
+	switch s {
+	case seqNone:
+		if r == ansi.Marker {
+			return seqEsc
+		}
+	case seqEsc:
+		switch r {
+		case '[':
+			return seqCSI
+		case ']', 'P', 'X', '^', '_':
+			return seqString
+		}
+		return seqNone
+	case seqCSI:
+		if r >= 0x40 && r <= 0x7e {
+			return seqNone
+		}
+		return seqCSI
+	case seqString, seqStringEsc:
+		switch {
+		case r == '\a':
+			return seqNone
+		case r == ansi.Marker:
+			return seqStringEsc
+		case s == seqStringEsc && r == '\\':
+			return seqNone
+		}
+		return seqString
+	}
+	return seqNone
 }
 
 // NewWriter returns a new writer that drops the given number of columns from a
@@ -30,7 +85,7 @@ func NewWriter(width uint, prefix string) *Writer {
 		width:  width,
 		prefix: prefix,
 	}
-	w.ansiWriter.Forward = &w.buf
+	w.forward = &w.buf
 	return w
 }
 
@@ -38,11 +93,9 @@ func NewWriter(width uint, prefix string) *Writer {
 // writer instead of its internal buffer.
 func NewWriterPipe(forward io.Writer, width uint, prefix string) *Writer {
 	return &Writer{
-		width:  width,
-		prefix: prefix,
-		ansiWriter: ansi.Writer{
-			Forward: forward,
-		},
+		width:   width,
+		prefix:  prefix,
+		forward: forward,
 	}
 }
 
@@ -88,39 +141,58 @@ func (w *Writer) Write(b []byte) (int, error) {
 	}
 
 	var currentWidth uint
-	for _, r := range string(b) {
-		if r == ansi.Marker {
-			// ANSI escape sequence
-			w.ansi = true
-		} else if w.ansi {
-			if ansi.IsTerminator(r) {
-				w.ansi = false
+	for s := string(b); len(s) > 0; {
+		if w.seq != seqNone || s[0] == ansi.Marker {
+			r, size := utf8.DecodeRuneInString(s)
+			w.seq = w.seq.next(r)
+			if _, err := io.WriteString(w.forward, s[:size]); err != nil {
+				return 0, err
 			}
-		} else if currentWidth < width {
-			rw := uint(runewidth.RuneWidth(r))
-			if len(w.prefix) > 0 && currentWidth+rw >= width {
-				_, err := w.ansiWriter.Write([]byte(w.prefix))
-				if err != nil {
-					return 0, err
+			s = s[size:]
+			continue
+		}
+
+		// Text up to the next escape sequence is measured by grapheme
+		// clusters, so emoji sequences, flags and combining marks are
+		// skipped whole.
+		text := s
+		if i := strings.IndexByte(s, ansi.Marker); i >= 0 {
+			text = s[:i]
+		}
+		s = s[len(text):]
+
+		state := -1
+		for len(text) > 0 {
+			var (
+				cluster string
+				cw      int
+			)
+			cluster, text, cw, state = uniseg.FirstGraphemeClusterInString(text, state)
+
+			if currentWidth < width {
+				rw := uint(cw)
+				if len(w.prefix) > 0 && currentWidth+rw >= width {
+					if _, err := io.WriteString(w.forward, w.prefix); err != nil {
+						return 0, err
+					}
 				}
-			}
 
-			if currentWidth+rw > width {
-				// double-width rune across the skip boundary.
-				// Add spaces to preserve alignment.
-				for currentWidth < width {
-					_, _ = w.ansiWriter.Write([]byte(" "))
-					currentWidth++
+				if currentWidth+rw > width {
+					// double-width cluster across the skip boundary.
+					// Add spaces to preserve alignment.
+					for currentWidth < width {
+						_, _ = io.WriteString(w.forward, " ")
+						currentWidth++
+					}
 				}
-			}
-			currentWidth += rw
+				currentWidth += rw
 
-			continue
-		}
+				continue
+			}
 
-		_, err := w.ansiWriter.Write([]byte(string(r)))
-		if err != nil {
-			return 0, err
+			if _, err := io.WriteString(w.forward, cluster); err != nil {
+				return 0, err
+			}
 		}
 	}
 
@@ -247,6 +247,86 @@ func tests() []test {
 			give:   "\x1B[38;2;249;38;114mhello你好\x1B[0m",
 			want:   "\x1B[38;2;249;38;114m… 好\x1B[0m",
 		},
+		{
+			name:  "hyperlink terminated by BEL",
+			width: 3,
+			give:  "\x1B]8;;https://example.com\afoobar\x1B]8;;\a",
+			want:  "\x1B]8;;https://example.com\abar\x1B]8;;\a",
+		},
+		{
+			name:  "hyperlink terminated by ST",
+			width: 3,
+			give:  "\x1B]8;;https://example.com\x1B\\foobar\x1B]8;;\x1B\\",
+			want:  "\x1B]8;;https://example.com\x1B\\bar\x1B]8;;\x1B\\",
+		},
+		{
+			name:   "hyperlink with prefix",
+			width:  3,
+			prefix: "…",
+			give:   "foo\x1B]8;;https://example.com\abar\x1B]8;;\a",
+			want:   "\x1B]8;;https://example.com\a…ar\x1B]8;;\a",
+		},
+		{
+			name:  "hyperlink in skipped columns",
+			width: 4,
+			give:  "f\x1B]8;;https://example.com\aoo\x1B]8;;\abar",
+			want:  "\x1B]8;;https://example.com\a\x1B]8;;\aar",
+		},
+		{
+			name:  "device control string",
+			width: 3,
+			give:  "\x1BPq#0;2;0;0;0\x1B\\foobar",
+			want:  "\x1BPq#0;2;0;0;0\x1B\\bar",
+		},
+		{
+			name:  "escape inside control string",
+			width: 3,
+			give:  "\x1B]2;a\x1Bb\afoobar",
+			want:  "\x1B]2;a\x1Bb\abar",
+		},
+		{
+			name:  "emoji zwj sequence",
+			width: 2,
+			give:  "👩‍💻ab",
+			want:  "ab",
+		},
+		{
+			name:  "emoji zwj sequence chopped and replaced by space",
+			width: 1,
+			give:  "👩‍💻ab",
+			want:  " ab",
+		},
+		{
+			name:   "emoji zwj sequence replaced by prefix",
+			width:  2,
+			prefix: "…",
+			give:   "👩‍💻ab",
+			want:   "…b",
+		},
+		{
+			name:  "flags",
+			width: 2,
+			give:  "🇩🇪🇫🇷",
+			want:  "🇫🇷",
+		},
+		{
+			name:  "combining marks",
+			width: 1,
+			give:  "e\u0301tude",
+			want:  "tude",
+		},
+		{
+			name:  "combining marks kept with their base",
+			width: 1,
+			give:  "ae\u0301",
+			want:  "e\u0301",
+		},
+		{
+			name:  "emoji with ansi",
+			width: 2,
+			give:  "\x1B[1m👍🏽\x1B[0m ok",
+			want:  "\x1B[1m\x1B[0m ok",
+		},
 	}
 }
 
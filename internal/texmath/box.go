@@ -0,0 +1,175 @@
+package texmath
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// box is a block of text with a baseline, the index of the line it lines up
+// with the boxes next to it on.
+type box struct {
+	lines []string
+	base  int
+}
+
+func text(s string) box {
+	return box{lines: []string{s}}
+}
+
+func (b box) width() int {
+	w := 0
+	for _, line := range b.lines {
+		w = max(w, runewidth.StringWidth(line))
+	}
+	return w
+}
+
+// center pads the lines of b to width, centering them.
+func (b box) center(width int) box {
+	out := box{lines: make([]string, len(b.lines)), base: b.base}
+	left := (width - b.width()) / 2
+	for i, line := range b.lines {
+		out.lines[i] = runewidth.FillRight(strings.Repeat(" ", left)+line, width)
+	}
+	return out
+}
+
+// hcat sets boxes next to each other, lining up their baselines.
+func hcat(boxes ...box) box {
+	above, below := 0, 0
+	for _, b := range boxes {
+		above = max(above, b.base)
+		below = max(below, len(b.lines)-b.base-1)
+	}
+
+	out := box{lines: make([]string, above+below+1), base: above}
+	for _, b := range boxes {
+		w := b.width()
+		for i := range out.lines {
+			line := ""
+			if j := i - above + b.base; j >= 0 && j < len(b.lines) {
+				line = b.lines[j]
+			}
+			out.lines[i] += runewidth.FillRight(line, w)
+		}
+	}
+	return out
+}
+
+// vstack stacks boxes centered on top of each other, the baseline being the
+// one of the box at index base.
+func vstack(base int, boxes ...box) box {
+	w := 0
+	for _, b := range boxes {
+		w = max(w, b.width())
+	}
+
+	var out box
+	for i, b := range boxes {
+		if i == base {
+			out.base = len(out.lines) + b.base
+		}
+		out.lines = append(out.lines, b.center(w).lines...)
+	}
+	return out
+}
+
+func display(n node) box {
+	switch n := n.(type) {
+	case atom:
+		return text(n.text)
+	case operator:
+		return text(n.text)
+	case row:
+		var boxes []box
+		for i, space := range spacing(n) {
+			if space != "" {
+				boxes = append(boxes, text(space))
+			}
+			boxes = append(boxes, display(n[i]))
+		}
+		if len(boxes) == 0 {
+			return text("")
+		}
+		return hcat(boxes...)
+	case frac:
+		num, den := display(n.num), display(n.den)
+		bar := text(strings.Repeat("─", max(num.width(), den.width())+2))
+		return vstack(1, num, bar, den)
+	case root:
+		return displayRoot(n)
+	case scripted:
+		return displayScripts(n)
+	}
+	return text("")
+}
+
+// displayRoot draws the root sign left of the body and a bar over it:
+//
+//	 ─────
+//	√x + 1
+func displayRoot(n root) box {
+	body := display(n.body)
+
+	index := ""
+	if n.index != nil {
+		if s, ok := script(strings.ReplaceAll(inline(n.index), " ", ""), superscripts); ok {
+			index = s
+		} else {
+			index = "(" + inline(n.index) + ")"
+		}
+	}
+	pad := strings.Repeat(" ", runewidth.StringWidth(index))
+
+	out := box{lines: []string{pad + " " + strings.Repeat("─", body.width())}, base: body.base + 1}
+	for i, line := range body.lines {
+		sign := pad + "│"
+		if i == len(body.lines)-1 {
+			sign = index + "√"
+		}
+		out.lines = append(out.lines, sign+line)
+	}
+	return out
+}
+
+// displayScripts sets scripts as Unicode superscripts and subscripts when
+// they have them, or else above and below the baseline. The limits of big
+// operators go above and below them.
+func displayScripts(n scripted) box {
+	base := display(n.base)
+
+	if op, ok := n.base.(operator); ok && op.limits {
+		boxes, index := []box{base}, 0
+		if n.sup != nil {
+			boxes, index = append([]box{display(n.sup)}, boxes...), 1
+		}
+		if n.sub != nil {
+			boxes = append(boxes, display(n.sub))
+		}
+		return vstack(index, boxes...)
+	}
+
+	sub, subOK := "", true
+	if n.sub != nil {
+		sub, subOK = script(strings.ReplaceAll(inline(n.sub), " ", ""), subscripts)
+	}
+	sup, supOK := "", true
+	if n.sup != nil {
+		sup, supOK = script(strings.ReplaceAll(inline(n.sup), " ", ""), superscripts)
+	}
+	if subOK && supOK {
+		return hcat(base, text(sub+sup))
+	}
+
+	var col box
+	if n.sup != nil {
+		col.lines = append(col.lines, display(n.sup).lines...)
+	}
+	col.base = len(col.lines)
+	col.lines = append(col.lines, "")
+	if n.sub != nil {
+		col.lines = append(col.lines, display(n.sub).lines...)
+	}
+	return hcat(base, col)
+}
@@ -0,0 +1,297 @@
+package texmath
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// class decides the spacing around a node, like the atom types of TeX.
+type class int
+
+const (
+	ord class = iota
+	bin
+	rel
+	fn
+	open
+	closing
+	punct
+)
+
+type node interface{}
+
+type (
+	// atom is a symbol or a run of text.
+	atom struct {
+		text  string
+		class class
+	}
+	// row is a list of nodes set next to each other, also used for groups.
+	row []node
+	// frac is a fraction.
+	frac struct{ num, den row }
+	// root is a square root, or an nth root with an index.
+	root struct{ index, body row }
+	// operator is a big operator, limits tells whether its scripts go above
+	// and below it in display mode.
+	operator struct {
+		text   string
+		limits bool
+	}
+	// scripted is a node with a subscript, a superscript or both, nil when
+	// missing.
+	scripted struct {
+		base     node
+		sub, sup row
+	}
+)
+
+type parser struct {
+	src []rune
+	pos int
+}
+
+func parse(src string) (row, error) {
+	p := &parser{src: []rune(src)}
+	r, err := p.row(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.src) {
+		return nil, fmt.Errorf("unexpected %q", p.src[p.pos])
+	}
+	return r, nil
+}
+
+func (p *parser) peek() rune {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+// row parses nodes until the end or, unless end is 0, the end rune, which is
+// consumed.
+func (p *parser) row(end rune) (row, error) {
+	r := row{}
+	for {
+		p.skipSpace()
+		c := p.peek()
+		switch {
+		case c == 0 && end != 0:
+			return nil, fmt.Errorf("missing %q", end)
+		case c == 0:
+			return r, nil
+		case c == end:
+			p.pos++
+			return r, nil
+		case c == '}':
+			return nil, fmt.Errorf("unexpected }")
+		case c == '^' || c == '_':
+			p.pos++
+			arg, err := p.arg()
+			if err != nil {
+				return nil, err
+			}
+
+			s, ok := scripted{}, false
+			if len(r) > 0 {
+				s, ok = r[len(r)-1].(scripted)
+				if !ok {
+					s = scripted{base: r[len(r)-1]}
+				}
+				r = r[:len(r)-1]
+			}
+			if c == '^' {
+				s.sup = append(s.sup, arg...)
+			} else {
+				s.sub = append(s.sub, arg...)
+			}
+			r = append(r, s)
+		default:
+			n, err := p.node()
+			if err != nil {
+				return nil, err
+			}
+			if n != nil {
+				r = append(r, n)
+			}
+		}
+	}
+}
+
+// arg parses the argument of a command or a script: a group or a single
+// node.
+func (p *parser) arg() (row, error) {
+	p.skipSpace()
+	switch p.peek() {
+	case 0:
+		return nil, fmt.Errorf("missing argument")
+	case '{':
+		p.pos++
+		return p.row('}')
+	}
+	n, err := p.node()
+	if err != nil || n == nil {
+		return nil, err
+	}
+	return row{n}, nil
+}
+
+// node parses a single node. It returns nil for commands that don't produce
+// one, like \left.
+func (p *parser) node() (node, error) {
+	c := p.src[p.pos]
+	p.pos++
+
+	switch c {
+	case '{':
+		return p.row('}')
+	case '\\':
+		return p.command()
+	case '+':
+		return atom{"+", bin}, nil
+	case '-':
+		return atom{"−", bin}, nil
+	case '*':
+		return atom{"∗", bin}, nil
+	case '=', '<', '>':
+		return atom{string(c), rel}, nil
+	case '(', '[':
+		return atom{string(c), open}, nil
+	case ')', ']':
+		return atom{string(c), closing}, nil
+	case ',', ';':
+		return atom{string(c), punct}, nil
+	case '\'':
+		return atom{"′", ord}, nil
+	case '&', '#', '%', '~':
+		return nil, fmt.Errorf("unsupported %q", c)
+	}
+
+	if unicode.IsDigit(c) || c == '.' {
+		// Numbers are kept together.
+		start := p.pos - 1
+		for p.pos < len(p.src) && (unicode.IsDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		return atom{string(p.src[start:p.pos]), ord}, nil
+	}
+	return atom{string(c), ord}, nil
+}
+
+func (p *parser) command() (node, error) {
+	start := p.pos
+	for p.pos < len(p.src) && unicode.IsLetter(p.src[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start && p.pos < len(p.src) {
+		p.pos++
+	}
+	name := string(p.src[start:p.pos])
+
+	switch name {
+	case "":
+		return nil, fmt.Errorf("missing command after \\")
+	case "frac", "dfrac", "tfrac":
+		num, err := p.arg()
+		if err != nil {
+			return nil, err
+		}
+		den, err := p.arg()
+		if err != nil {
+			return nil, err
+		}
+		return frac{num, den}, nil
+	case "sqrt":
+		var r root
+		p.skipSpace()
+		if p.peek() == '[' {
+			p.pos++
+			index, err := p.row(']')
+			if err != nil {
+				return nil, err
+			}
+			r.index = index
+		}
+		body, err := p.arg()
+		if err != nil {
+			return nil, err
+		}
+		r.body = body
+		return r, nil
+	case "left", "right", "big", "Big", "bigg", "Bigg", "displaystyle":
+		// Delimiters don't grow, the one following is parsed as usual.
+		p.skipSpace()
+		if p.peek() == '.' {
+			p.pos++
+		}
+		return nil, nil
+	case "text", "textrm", "textbf", "textit", "mathrm", "mathbf", "mathit", "mathsf", "mathtt", "mathcal", "operatorname", "mbox":
+		text, err := p.raw()
+		if err != nil {
+			return nil, err
+		}
+		if name == "operatorname" {
+			return atom{text, fn}, nil
+		}
+		return atom{text, ord}, nil
+	}
+
+	if s, ok := greek[name]; ok {
+		return atom{s, ord}, nil
+	}
+	if a, ok := symbols[name]; ok {
+		switch a.text {
+		case "⟨", "⌊", "⌈", "{":
+			a.class = open
+		case "⟩", "⌋", "⌉", "}":
+			a.class = closing
+		}
+		return a, nil
+	}
+	if s, ok := operators[name]; ok {
+		return operator{s, true}, nil
+	}
+	if s, ok := integrals[name]; ok {
+		return operator{s, false}, nil
+	}
+	if slices.Contains(functions, name) {
+		return atom{name, fn}, nil
+	}
+	return nil, fmt.Errorf("unknown command \\%s", name)
+}
+
+// raw reads the argument of text commands as is.
+func (p *parser) raw() (string, error) {
+	p.skipSpace()
+	if p.peek() != '{' {
+		return "", fmt.Errorf("missing argument")
+	}
+	p.pos++
+
+	var b strings.Builder
+	for depth := 1; ; p.pos++ {
+		if p.pos >= len(p.src) {
+			return "", fmt.Errorf("missing %q", '}')
+		}
+		switch c := p.src[p.pos]; c {
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				p.pos++
+				return b.String(), nil
+			}
+		}
+		b.WriteRune(p.src[p.pos])
+	}
+}
@@ -0,0 +1,107 @@
+package texmath
+
+var greek = map[string]string{
+	"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ", "epsilon": "ϵ",
+	"varepsilon": "ε", "zeta": "ζ", "eta": "η", "theta": "θ", "vartheta": "ϑ",
+	"iota": "ι", "kappa": "κ", "lambda": "λ", "mu": "μ", "nu": "ν", "xi": "ξ",
+	"pi": "π", "varpi": "ϖ", "rho": "ρ", "varrho": "ϱ", "sigma": "σ",
+	"varsigma": "ς", "tau": "τ", "upsilon": "υ", "phi": "ϕ", "varphi": "φ",
+	"chi": "χ", "psi": "ψ", "omega": "ω",
+	"Gamma": "Γ", "Delta": "Δ", "Theta": "Θ", "Lambda": "Λ", "Xi": "Ξ",
+	"Pi": "Π", "Sigma": "Σ", "Upsilon": "Υ", "Phi": "Φ", "Psi": "Ψ",
+	"Omega": "Ω",
+}
+
+// symbols maps commands to the symbol they stand for and its class, which
+// decides the spacing around it.
+var symbols = map[string]atom{
+	"infty": {"∞", ord}, "partial": {"∂", ord}, "nabla": {"∇", ord},
+	"hbar": {"ℏ", ord}, "ell": {"ℓ", ord}, "emptyset": {"∅", ord},
+	"forall": {"∀", ord}, "exists": {"∃", ord}, "neg": {"¬", ord},
+	"prime": {"′", ord}, "ldots": {"…", ord}, "cdots": {"⋯", ord},
+	"vdots": {"⋮", ord}, "ddots": {"⋱", ord}, "dots": {"…", ord},
+	"angle": {"∠", ord}, "degree": {"°", ord},
+
+	"pm": {"±", bin}, "mp": {"∓", bin}, "times": {"×", bin}, "div": {"÷", bin},
+	"cdot": {"·", bin}, "ast": {"∗", bin}, "circ": {"∘", bin},
+	"bullet": {"•", bin}, "oplus": {"⊕", bin}, "otimes": {"⊗", bin},
+	"cap": {"∩", bin}, "cup": {"∪", bin}, "wedge": {"∧", bin}, "land": {"∧", bin},
+	"vee": {"∨", bin}, "lor": {"∨", bin}, "setminus": {"∖", bin},
+
+	"leq": {"≤", rel}, "le": {"≤", rel}, "geq": {"≥", rel}, "ge": {"≥", rel},
+	"neq": {"≠", rel}, "ne": {"≠", rel}, "approx": {"≈", rel},
+	"equiv": {"≡", rel}, "sim": {"∼", rel}, "simeq": {"≃", rel},
+	"cong": {"≅", rel}, "propto": {"∝", rel}, "ll": {"≪", rel}, "gg": {"≫", rel},
+	"in": {"∈", rel}, "notin": {"∉", rel}, "ni": {"∋", rel},
+	"subset": {"⊂", rel}, "supset": {"⊃", rel}, "subseteq": {"⊆", rel},
+	"supseteq": {"⊇", rel}, "to": {"→", rel}, "rightarrow": {"→", rel},
+	"leftarrow": {"←", rel}, "gets": {"←", rel}, "leftrightarrow": {"↔", rel},
+	"Rightarrow": {"⇒", rel}, "Leftarrow": {"⇐", rel},
+	"Leftrightarrow": {"⇔", rel}, "implies": {"⟹", rel}, "iff": {"⟺", rel},
+	"mapsto": {"↦", rel}, "perp": {"⊥", rel}, "parallel": {"∥", rel},
+	"mid": {"∣", rel},
+
+	"langle": {"⟨", ord}, "rangle": {"⟩", ord}, "lfloor": {"⌊", ord},
+	"rfloor": {"⌋", ord}, "lceil": {"⌈", ord}, "rceil": {"⌉", ord},
+	"{": {"{", ord}, "}": {"}", ord}, "|": {"‖", ord}, "$": {"$", ord},
+	"%": {"%", ord}, "&": {"&", ord}, "#": {"#", ord}, "_": {"_", ord},
+
+	",": {" ", ord}, ":": {" ", ord}, ";": {" ", ord}, " ": {" ", ord},
+	"!": {"", ord}, "quad": {"  ", ord}, "qquad": {"    ", ord},
+}
+
+// operators are the big operators, drawn with their limits above and below
+// them in display mode.
+var operators = map[string]string{
+	"sum": "∑", "prod": "∏", "coprod": "∐", "bigcup": "⋃", "bigcap": "⋂",
+	"lim": "lim", "max": "max", "min": "min", "sup": "sup", "inf": "inf",
+}
+
+// integrals keep their limits as scripts like in LaTeX.
+var integrals = map[string]string{
+	"int": "∫", "iint": "∬", "iiint": "∭", "oint": "∮",
+}
+
+// functions are set upright in LaTeX, here they are just words.
+var functions = []string{
+	"sin", "cos", "tan", "cot", "sec", "csc", "arcsin", "arccos", "arctan",
+	"sinh", "cosh", "tanh", "log", "ln", "lg", "exp", "det", "dim", "ker",
+	"deg", "gcd", "arg", "Pr", "mod",
+}
+
+var superscripts = map[rune]rune{
+	'0': '⁰', '1': '¹', '2': '²', '3': '³', '4': '⁴', '5': '⁵', '6': '⁶',
+	'7': '⁷', '8': '⁸', '9': '⁹', '+': '⁺', '-': '⁻', '−': '⁻', '=': '⁼',
+	'(': '⁽', ')': '⁾', 'a': 'ᵃ', 'b': 'ᵇ', 'c': 'ᶜ', 'd': 'ᵈ', 'e': 'ᵉ',
+	'f': 'ᶠ', 'g': 'ᵍ', 'h': 'ʰ', 'i': 'ⁱ', 'j': 'ʲ', 'k': 'ᵏ', 'l': 'ˡ',
+	'm': 'ᵐ', 'n': 'ⁿ', 'o': 'ᵒ', 'p': 'ᵖ', 'r': 'ʳ', 's': 'ˢ', 't': 'ᵗ',
+	'u': 'ᵘ', 'v': 'ᵛ', 'w': 'ʷ', 'x': 'ˣ', 'y': 'ʸ', 'z': 'ᶻ', 'A': 'ᴬ',
+	'B': 'ᴮ', 'D': 'ᴰ', 'E': 'ᴱ', 'G': 'ᴳ', 'H': 'ᴴ', 'I': 'ᴵ', 'J': 'ᴶ',
+	'K': 'ᴷ', 'L': 'ᴸ', 'M': 'ᴹ', 'N': 'ᴺ', 'O': 'ᴼ', 'P': 'ᴾ', 'R': 'ᴿ',
+	'T': 'ᵀ', 'U': 'ᵁ', 'V': 'ⱽ', 'W': 'ᵂ', 'α': 'ᵅ', 'β': 'ᵝ', 'γ': 'ᵞ',
+	'δ': 'ᵟ', 'θ': 'ᶿ', 'ι': 'ᶥ', 'ϕ': 'ᵠ', 'φ': 'ᵠ', 'χ': 'ᵡ', '′': '′',
+	'*': '*', '∗': '*', ' ': ' ',
+}
+
+var subscripts = map[rune]rune{
+	'0': '₀', '1': '₁', '2': '₂', '3': '₃', '4': '₄', '5': '₅', '6': '₆',
+	'7': '₇', '8': '₈', '9': '₉', '+': '₊', '-': '₋', '−': '₋', '=': '₌',
+	'(': '₍', ')': '₎', 'a': 'ₐ', 'e': 'ₑ', 'h': 'ₕ', 'i': 'ᵢ', 'j': 'ⱼ',
+	'k': 'ₖ', 'l': 'ₗ', 'm': 'ₘ', 'n': 'ₙ', 'o': 'ₒ', 'p': 'ₚ', 'r': 'ᵣ',
+	's': 'ₛ', 't': 'ₜ', 'u': 'ᵤ', 'v': 'ᵥ', 'x': 'ₓ', 'β': 'ᵦ', 'γ': 'ᵧ',
+	'ρ': 'ᵨ', 'ϕ': 'ᵩ', 'φ': 'ᵩ', 'χ': 'ᵪ', ' ': ' ',
+}
+
+// script maps every rune of s with m, reporting false if one of them has no
+// mapping.
+func script(s string, m map[rune]rune) (string, bool) {
+	out := make([]rune, 0, len(s))
+	for _, r := range s {
+		mapped, ok := m[r]
+		if !ok {
+			return "", false
+		}
+		out = append(out, mapped)
+	}
+	return string(out), true
+}
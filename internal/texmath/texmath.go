@@ -0,0 +1,137 @@
+// Package texmath renders a subset of LaTeX math as Unicode text: Greek
+// letters and symbols, superscripts and subscripts, fractions, roots and big
+// operators like sums.
+package texmath
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Inline renders src on a single line, using Unicode superscripts and
+// subscripts where they exist and slashes for fractions.
+func Inline(src string) (string, error) {
+	r, err := parse(src)
+	if err != nil {
+		return "", err
+	}
+	return inline(r), nil
+}
+
+// Display renders src over as many lines as it takes to stack fractions and
+// the limits of big operators. All lines have the same width.
+func Display(src string) ([]string, error) {
+	r, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	b := display(r)
+	for i, line := range b.lines {
+		b.lines[i] = runewidth.FillRight(line, b.width())
+	}
+	return b.lines, nil
+}
+
+func classOf(n node) class {
+	switch n := n.(type) {
+	case atom:
+		return n.class
+	case operator:
+		return fn
+	case scripted:
+		return classOf(n.base)
+	}
+	return ord
+}
+
+// spacing returns the spaces to put before every node of r, following the
+// spacing of TeX loosely: binary operators and relations are surrounded by
+// spaces and functions and punctuation followed by one.
+func spacing(r row) []string {
+	classes := make([]class, len(r))
+	for i, n := range r {
+		classes[i] = classOf(n)
+		if classes[i] == bin && (i == 0 || i == len(r)-1 || classes[i-1] == bin ||
+			classes[i-1] == rel || classes[i-1] == open || classes[i-1] == punct) {
+			// Unary, like a sign.
+			classes[i] = ord
+		}
+	}
+
+	spaces := make([]string, len(r))
+	for i := 1; i < len(r); i++ {
+		prev, cur := classes[i-1], classes[i]
+		switch {
+		case prev == rel || cur == rel || prev == bin || cur == bin || prev == punct:
+			spaces[i] = " "
+		case prev == fn && cur != open && cur != fn:
+			spaces[i] = " "
+		}
+	}
+	return spaces
+}
+
+func inline(n node) string {
+	switch n := n.(type) {
+	case atom:
+		return n.text
+	case operator:
+		return n.text
+	case row:
+		var b strings.Builder
+		for i, space := range spacing(n) {
+			b.WriteString(space)
+			b.WriteString(inline(n[i]))
+		}
+		return b.String()
+	case frac:
+		return parenthesize(n.num) + "/" + parenthesize(n.den)
+	case root:
+		body := parenthesize(n.body)
+		switch index := inline(n.index); index {
+		case "":
+			return "√" + body
+		case "3":
+			return "∛" + body
+		case "4":
+			return "∜" + body
+		default:
+			if s, ok := script(index, superscripts); ok {
+				return s + "√" + body
+			}
+			return "(" + index + ")√" + body
+		}
+	case scripted:
+		out := inline(n.base)
+		if n.sub != nil {
+			out += scriptInline(n.sub, subscripts, "_")
+		}
+		if n.sup != nil {
+			out += scriptInline(n.sup, superscripts, "^")
+		}
+		return out
+	}
+	return ""
+}
+
+// scriptInline renders a script with Unicode characters, falling back to
+// marking it like LaTeX does.
+func scriptInline(r row, m map[rune]rune, mark string) string {
+	// Scripts are set tight.
+	text := strings.ReplaceAll(inline(r), " ", "")
+	if s, ok := script(text, m); ok {
+		return s
+	}
+	return mark + parenthesize(r)
+}
+
+// parenthesize renders r inline, in parentheses unless it is a single node.
+func parenthesize(r row) string {
+	if len(r) == 1 {
+		if _, ok := r[0].(frac); !ok {
+			return inline(r)
+		}
+	}
+	return "(" + inline(r) + ")"
+}
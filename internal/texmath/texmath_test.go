@@ -0,0 +1,136 @@
+package texmath
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInline(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		want string
+	}{
+		{"superscripts", `x^2 + y^2 = z^2`, "x² + y² = z²"},
+		{"greek", `\alpha \beta`, "αβ"},
+		{"subscript", `a_i`, "aᵢ"},
+		{"grouped superscript", `x^{n+1}`, "xⁿ⁺¹"},
+		{"superscript fallback", `e^{i\pi} = -1`, "e^(iπ) = −1"},
+		{"subscript fallback", `x_q`, "x_q"},
+		{"fraction", `\frac{1}{2}`, "1/2"},
+		{"fraction parenthesized", `\frac{a+b}{c}`, "(a + b)/c"},
+		{"root", `\sqrt{x}`, "√x"},
+		{"cube root", `\sqrt[3]{x}`, "∛x"},
+		{"sum", `\sum_{i=1}^{n} i`, "∑ᵢ₌₁ⁿ i"},
+		{"integral", `\int_0^1 f(x)\,dx`, "∫₀¹ f(x) dx"},
+		{"function", `\sin x`, "sin x"},
+		{"delimiters", `\left( x \right)`, "(x)"},
+		{"relations", `a \leq b \to c`, "a ≤ b → c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Inline(tt.give)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDisplay(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		want []string
+	}{
+		{
+			name: "single line",
+			give: `x^2 + y^2 = z^2`,
+			want: []string{"x² + y² = z²"},
+		},
+		{
+			name: "fraction",
+			give: `\frac{a+b}{c}`,
+			want: []string{
+				" a + b ",
+				"───────",
+				"   c   ",
+			},
+		},
+		{
+			name: "root",
+			give: `\sqrt[3]{x}`,
+			want: []string{
+				"  ─",
+				"³√x",
+			},
+		},
+		{
+			name: "sum limits",
+			give: `\sum_{i=1}^{n} i`,
+			want: []string{
+				"  n    ",
+				"  ∑   i",
+				"i = 1  ",
+			},
+		},
+		{
+			name: "stacked scripts",
+			give: `e^{i\pi} = -1`,
+			want: []string{
+				" iπ     ",
+				"e   = −1",
+			},
+		},
+		{
+			name: "subscript below",
+			give: `x_q`,
+			want: []string{
+				"x ",
+				" q",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Display(tt.give)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected:\n\n%s\n\nActual Output:\n\n%s", strings.Join(tt.want, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		want string
+	}{
+		{"unclosed brace", `{a`, "missing '}'"},
+		{"unopened brace", `a}`, "unexpected }"},
+		{"missing fraction argument", `\frac{a}`, "missing argument"},
+		{"missing script", `x^`, "missing argument"},
+		{"unknown command", `\unknown`, `unknown command \unknown`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Inline(tt.give); err == nil || err.Error() != tt.want {
+				t.Errorf("Inline: expected error %q, got %v", tt.want, err)
+			}
+			if _, err := Display(tt.give); err == nil || err.Error() != tt.want {
+				t.Errorf("Display: expected error %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
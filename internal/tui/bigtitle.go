@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/museslabs/kyma/internal/bigtext"
+	"github.com/museslabs/kyma/internal/markdown"
+)
+
+var (
+	// bigHeadingRe matches headings written as `#! Title`, which are always
+	// rendered as big text.
+	bigHeadingRe = regexp.MustCompile(`^ {0,3}#!\s+(.*?)\s*$`)
+	h1Re         = regexp.MustCompile(`^ {0,3}#\s+(.*?)(\s+#+)?\s*$`)
+)
+
+// parseBigTitles replaces the headings to render as big text in data with
+// embeds. With a font set, the first H1 of the slide is rendered as big text
+// too.
+func parseBigTitles(data, font string, e *embeds, theme ansi.StyleConfig) string {
+	var (
+		out    []string
+		fences markdown.Fences
+		h1Done = font == ""
+	)
+	if font == "" {
+		font = bigtext.DefaultFont
+	}
+
+	for _, line := range strings.Split(data, "\n") {
+		var m []string
+		if fences.Next(line) == markdown.Text {
+			m = bigHeadingRe.FindStringSubmatch(line)
+			if m == nil && !h1Done {
+				if m = h1Re.FindStringSubmatch(line); m != nil {
+					h1Done = true
+				}
+			}
+		}
+		if m == nil {
+			out = append(out, line)
+			continue
+		}
+
+		out = append(out, e.add(bigTitle(m[1], font, theme)))
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// bigTitle renders text as big text colored like the headings of the theme,
+// shrinking and wrapping it to fit. Titles that don't fit at all are rendered
+// as bold text.
+func bigTitle(text, font string, theme ansi.StyleConfig) embed {
+	style := lipgloss.NewStyle()
+	for _, c := range []*string{theme.H1.Color, theme.Heading.Color, theme.Document.Color} {
+		if c != nil {
+			style = style.Foreground(lipgloss.Color(*c))
+			break
+		}
+	}
+
+	return func(width int) []string {
+		big, ok := bigtext.Fit(text, font, width)
+		if !ok {
+			return []string{style.Bold(true).Render(text)}
+		}
+
+		for i := range big {
+			big[i] = style.Render(big[i])
+		}
+		return big
+	}
+}
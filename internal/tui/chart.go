@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/museslabs/kyma/internal/chart"
+)
+
+// parseCharts replaces the chart fenced blocks in data with embeds drawing
+// them. The info string holds the kind of chart and, for line charts, an
+// optional height:
+//
+//	```chart line height=12
+func parseCharts(data string, e *embeds, theme ansi.StyleConfig) string {
+	style := chartStyle(theme)
+	return replaceFences(data, func(info string, body []string) (string, bool) {
+		fields := strings.Fields(info)
+		if len(fields) == 0 || fields[0] != "chart" {
+			return "", false
+		}
+
+		kind, height := "bar", chart.DefaultHeight
+		var err error
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			switch {
+			case !ok:
+				kind = field
+			case key == "height":
+				if height, err = strconv.Atoi(value); err != nil || height < 2 {
+					err = fmt.Errorf("invalid height %q, expected at least 2 rows", value)
+				}
+			}
+		}
+
+		src := strings.Join(body, "\n")
+		return e.add(func(width int) []string {
+			if err != nil {
+				return embedError("Chart", err)
+			}
+			out, err := chart.Render(kind, src, width, height, style)
+			if err != nil {
+				return embedError("Chart", err)
+			}
+			return out
+		}), true
+	})
+}
+
+// chartStyle colors the series of charts with the accent colors of the theme,
+// followed by a fixed palette.
+func chartStyle(theme ansi.StyleConfig) chart.Style {
+	var colors []string
+	for _, c := range []*string{theme.H1.BackgroundColor, theme.Heading.Color, theme.Link.Color, theme.Code.Color} {
+		if c != nil {
+			colors = append(colors, *c)
+		}
+	}
+	colors = append(colors, "212", "86", "214")
+
+	var style chart.Style
+	seen := map[string]bool{}
+	for _, c := range colors {
+		if !seen[c] {
+			seen[c] = true
+			style.Series = append(style.Series, lipgloss.NewStyle().Foreground(lipgloss.Color(c)))
+		}
+	}
+
+	style.Text = lipgloss.NewStyle().Foreground(lipgloss.Color("245")) // Gray
+	if c := theme.Document.Color; c != nil {
+		style.Text = style.Text.Foreground(lipgloss.Color(*c))
+	}
+	return style
+}
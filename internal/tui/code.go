@@ -0,0 +1,263 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
+)
+
+// highlightRe matches a line highlight spec at the end of a code fence info
+// string, like {3,5-7} or {1|3-4|7} to step through the highlights.
+var highlightRe = regexp.MustCompile(`\s*\{([0-9,\-| ]*)\}\s*$`)
+
+var (
+	dimStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	lineNumberStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+)
+
+// gutterSeparator separates line numbers from the code.
+const gutterSeparator = " │ "
+
+// tabWidth matches the width lipgloss renders tabs with.
+const tabWidth = 4
+
+// CodeStyle is the styling applied to code blocks on top of what glamour
+// renders.
+type CodeStyle struct {
+	LineNumbers bool
+	Background  string
+	Padding     []int
+}
+
+func (s CodeStyle) boxed() bool {
+	return s.LineNumbers || s.Background != "" || len(s.Padding) > 0
+}
+
+// codeBlock is a fenced code block of a slide. Each step holds the
+// highlighted lines, 1-based and relative to the block, there are no steps
+// when no lines are highlighted.
+type codeBlock struct {
+	info  string
+	code  []string
+	steps []map[int]bool
+}
+
+// newCodeBlock returns the code block with the given fence info string and
+// lines, taking the highlight spec out of the info string.
+func newCodeBlock(info string, lines []string) codeBlock {
+	b := codeBlock{info: info, code: lines}
+	if m := highlightRe.FindStringSubmatch(info); m != nil {
+		if steps := parseSteps(m[1]); steps != nil {
+			b.info = info[:len(info)-len(m[0])]
+			b.steps = steps
+		}
+	}
+	for len(b.code) > 0 && strings.TrimSpace(b.code[len(b.code)-1]) == "" {
+		b.code = b.code[:len(b.code)-1]
+	}
+	return b
+}
+
+// parseCodeBlocks returns the code blocks in data in order.
+func parseCodeBlocks(data string) []codeBlock {
+	var blocks []codeBlock
+	replaceFences(data, func(info string, body []string) (string, bool) {
+		blocks = append(blocks, newCodeBlock(info, body))
+		return "", false
+	})
+	return blocks
+}
+
+// parseSteps parses a spec like 1|3-4,6|7, returning nil when it is invalid.
+func parseSteps(spec string) []map[int]bool {
+	var steps []map[int]bool
+	for _, step := range strings.Split(spec, "|") {
+		highlighted := map[int]bool{}
+		for _, r := range strings.Split(step, ",") {
+			r = strings.TrimSpace(r)
+			if r == "" {
+				continue
+			}
+
+			from, to, isRange := strings.Cut(r, "-")
+			if !isRange {
+				to = from
+			}
+			start, err := strconv.Atoi(strings.TrimSpace(from))
+			if err != nil || start < 1 {
+				return nil
+			}
+			end, err := strconv.Atoi(strings.TrimSpace(to))
+			if err != nil || end < start {
+				return nil
+			}
+			for n := start; n <= end; n++ {
+				highlighted[n] = true
+			}
+		}
+		steps = append(steps, highlighted)
+	}
+	return steps
+}
+
+// stepCount returns the number of highlight steps of the slide, the longest
+// stepping of its code blocks.
+func stepCount(blocks []codeBlock) int {
+	steps := 1
+	for _, b := range blocks {
+		steps = max(steps, len(b.steps))
+	}
+	return steps
+}
+
+// parseCode replaces the code blocks of data that kyma styles on top of
+// glamour, the ones with highlighted lines or all of them with a boxed style,
+// with embeds showing them at the given step.
+func parseCode(data string, e *embeds, step int, style CodeStyle, theme ansi.StyleConfig) string {
+	return replaceFences(data, func(info string, body []string) (string, bool) {
+		b := newCodeBlock(info, body)
+		if len(b.code) == 0 || len(b.steps) == 0 && !style.boxed() {
+			return "", false
+		}
+		return e.add(b.embed(step, style, theme)), true
+	})
+}
+
+// embed renders the code block like glamour does, but on its own and without
+// wrapping so that every line of code maps to a rendered line. Lines too long
+// for the slide are cut instead.
+func (b codeBlock) embed(step int, style CodeStyle, theme ansi.StyleConfig) embed {
+	return func(width int) []string {
+		fence := "```"
+		for strings.Contains(strings.Join(b.code, "\n"), fence) {
+			fence += "`"
+		}
+
+		// The embed is placed at the margin of the document already.
+		theme.Document.Margin = nil
+		theme.Document.BlockPrefix, theme.Document.BlockSuffix = "", ""
+
+		r, err := glamour.NewTermRenderer(glamour.WithStyles(theme), glamour.WithWordWrap(0))
+		if err != nil {
+			return embedError("Code", err)
+		}
+		out, err := r.Render(fence + b.info + "\n" + strings.Join(b.code, "\n") + "\n" + fence)
+		if err != nil {
+			return embedError("Code", err)
+		}
+
+		lines := strings.Split(out, "\n")
+		start := findCode(lines, 0, b.code)
+		if start < 0 {
+			return embedError("Code", fmt.Errorf("code block not found in the rendered output"))
+		}
+		return b.render(lines[start:start+len(b.code)], step, style, width)
+	}
+}
+
+// render styles the rendered lines of the code block to fit in width,
+// dimming the lines that aren't highlighted at the given step.
+func (b codeBlock) render(lines []string, step int, style CodeStyle, width int) []string {
+	rendered := make([]string, len(lines))
+	for i, line := range lines {
+		rendered[i] = strings.ReplaceAll(line, "\t", strings.Repeat(" ", tabWidth))
+	}
+
+	if len(b.steps) > 0 {
+		highlighted := b.steps[min(step, len(b.steps)-1)]
+		for i := range rendered {
+			if !highlighted[i+1] {
+				rendered[i] = dimStyle.Render(xansi.Strip(rendered[i]))
+			}
+		}
+	}
+
+	if !style.boxed() {
+		for i := range rendered {
+			rendered[i] = xansi.Truncate(rendered[i], width, "…")
+		}
+		return rendered
+	}
+
+	// Everything in front of the code, like the margin of code blocks, stays
+	// outside of the box.
+	margin := -1
+	codeWidth := 0
+	for i, line := range rendered {
+		code := visibleWidth(b.code[i])
+		if code == 0 {
+			continue
+		}
+		if margin < 0 {
+			margin = visibleWidth(xansi.Strip(line)) - code
+		}
+		codeWidth = max(codeWidth, code)
+	}
+	margin = max(margin, 0)
+
+	var gutter int
+	if style.LineNumbers {
+		gutter = len(strconv.Itoa(len(rendered)))
+	}
+	box := lipgloss.NewStyle().Padding(style.Padding...)
+	room := width - margin - box.GetHorizontalPadding()
+	if gutter > 0 {
+		room -= gutter + xansi.StringWidth(gutterSeparator)
+	}
+	codeWidth = max(min(codeWidth, room), 1)
+
+	code := make([]string, len(rendered))
+	for i, line := range rendered {
+		code[i] = xansi.Truncate(xansi.Cut(line, margin, xansi.StringWidth(line)), codeWidth, "…")
+		code[i] += strings.Repeat(" ", codeWidth-xansi.StringWidth(code[i]))
+		if gutter > 0 {
+			code[i] = lineNumberStyle.Render(fmt.Sprintf("%*d%s", gutter, i+1, gutterSeparator)) + code[i]
+		}
+	}
+
+	body := strings.Join(code, "\n")
+	if style.Background != "" {
+		box = box.Background(lipgloss.Color(style.Background))
+		body = fillBackground(body, style.Background)
+	}
+
+	blank := strings.Repeat(" ", margin)
+	boxed := strings.Split(box.Render(body), "\n")
+	for i := range boxed {
+		boxed[i] = blank + boxed[i]
+	}
+	return boxed
+}
+
+func visibleWidth(s string) int {
+	s = strings.ReplaceAll(s, "\t", strings.Repeat(" ", tabWidth))
+	return xansi.StringWidth(strings.TrimRight(s, " "))
+}
+
+// findCode returns the index of the first of the rendered lines, from the
+// given one on, showing the code, or -1.
+func findCode(lines []string, from int, code []string) int {
+	for start := from; start+len(code) <= len(lines); start++ {
+		match := true
+		for i, c := range code {
+			if normalize(xansi.Strip(lines[start+i])) != normalize(c) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return start
+		}
+	}
+	return -1
+}
+
+func normalize(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
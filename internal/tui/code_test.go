@@ -0,0 +1,266 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+func TestParseSteps(t *testing.T) {
+	tt := []struct {
+		spec     string
+		expected []map[int]bool
+	}{
+		{"3", []map[int]bool{{3: true}}},
+		{"1,3-5", []map[int]bool{{1: true, 3: true, 4: true, 5: true}}},
+		{" 2 - 3 , 5 ", []map[int]bool{{2: true, 3: true, 5: true}}},
+		{"1|2-3|4", []map[int]bool{{1: true}, {2: true, 3: true}, {4: true}}},
+		{"1||2", []map[int]bool{{1: true}, {}, {2: true}}},
+		{"", []map[int]bool{{}}},
+		{"0", nil},
+		{"3-1", nil},
+		{"1-", nil},
+		{"a", nil},
+	}
+
+	for _, tc := range tt {
+		if actual := parseSteps(tc.spec); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("parseSteps(%q): expected %v, got %v", tc.spec, tc.expected, actual)
+		}
+	}
+}
+
+func TestParseCodeBlocks(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    string
+		expected []codeBlock
+	}{
+		{
+			name:     "highlights",
+			input:    "text\n```go {1|2}\na\nb\n```",
+			expected: []codeBlock{{info: "go", code: []string{"a", "b"}, steps: []map[int]bool{{1: true}, {2: true}}}},
+		},
+		{
+			name:     "no highlights",
+			input:    "~~~sh\nls\n\n\n~~~",
+			expected: []codeBlock{{info: "sh", code: []string{"ls"}}},
+		},
+		{
+			name:     "invalid highlights",
+			input:    "```go {0}\na\n```",
+			expected: []codeBlock{{info: "go {0}", code: []string{"a"}}},
+		},
+		{
+			name:  "several",
+			input: "```\na\n```\ntext\n````md {2}\n```\nb\n```\n````",
+			expected: []codeBlock{
+				{code: []string{"a"}},
+				{info: "md", code: []string{"```", "b", "```"}, steps: []map[int]bool{{2: true}}},
+			},
+		},
+		{
+			name:  "none",
+			input: "text `code`",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := parseCodeBlocks(tc.input); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestStepCount(t *testing.T) {
+	blocks := parseCodeBlocks("```\na\n```\n```go {1|2|1,2}\na\nb\n```\n```go {1|2}\na\nb\n```")
+	if actual := stepCount(blocks); actual != 3 {
+		t.Errorf("expected 3 steps, got %d", actual)
+	}
+	if actual := stepCount(nil); actual != 1 {
+		t.Errorf("expected 1 step without code, got %d", actual)
+	}
+}
+
+// renderSlideCode renders data like a slide with the given code style and
+// width, returning the lines with their styling stripped.
+func renderSlideCode(t *testing.T, data string, step int, style CodeStyle, width int) []string {
+	t.Helper()
+
+	var e embeds
+	data = parseCode(data, &e, step, style, styles.NoTTYStyleConfig)
+	out, err := render(data, styles.NoTTYStyleConfig, width)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out = e.render(out, width)
+
+	var lines []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimRight(xansi.Strip(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func TestRenderCode(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    string
+		style    CodeStyle
+		width    int
+		expected []string
+	}{
+		{
+			name:     "highlights",
+			input:    "```go {1}\nx := 1\ny := 2\n```",
+			width:    40,
+			expected: []string{"    x := 1", "    y := 2"},
+		},
+		{
+			name:  "wrapped lines are cut",
+			input: "text\n\n```go {2}\nx := 1\nfmt.Println(\"a line too long for the slide\")\ny := 2\n```",
+			width: 30,
+			expected: []string{
+				"  text",
+				"    x := 1",
+				"    fmt.Println(\"a line too l…",
+				"    y := 2",
+			},
+		},
+		{
+			name:     "line numbers",
+			input:    "```\na\nb\n```",
+			style:    CodeStyle{LineNumbers: true},
+			width:    40,
+			expected: []string{"    1 │ a", "    2 │ b"},
+		},
+		{
+			name:  "line numbers past 9 lines",
+			input: "```\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n```",
+			style: CodeStyle{LineNumbers: true},
+			width: 40,
+			expected: []string{
+				"     1 │ 1", "     2 │ 2", "     3 │ 3", "     4 │ 4", "     5 │ 5",
+				"     6 │ 6", "     7 │ 7", "     8 │ 8", "     9 │ 9", "    10 │ 10",
+			},
+		},
+		{
+			name:     "line numbers and padding",
+			input:    "```\nfunc main() {}\n```",
+			style:    CodeStyle{LineNumbers: true, Padding: []int{0, 1}},
+			width:    20,
+			expected: []string{"     1 │ func main…"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := renderSlideCode(t, tc.input, 0, tc.style, tc.width)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected\n%s\ngot\n%s", strings.Join(tc.expected, "\n"), strings.Join(actual, "\n"))
+			}
+		})
+	}
+}
+
+func TestRenderCodeStyles(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	b := newCodeBlock("go {2}", []string{"x := 1", "long := 2"})
+	lines := []string{"  x := 1", "  long := 2"}
+
+	t.Run("dimmed", func(t *testing.T) {
+		actual := b.render(lines, 0, CodeStyle{}, 40)
+		expected := []string{dimStyle.Render("  x := 1"), "  long := 2"}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected %q, got %q", expected, actual)
+		}
+	})
+
+	t.Run("dimmed when too long for the slide", func(t *testing.T) {
+		var e embeds
+		data := parseCode("```go {1}\nx := 1\nfmt.Println(\"a line too long for the slide\")\n```", &e, 0, CodeStyle{}, styles.NoTTYStyleConfig)
+		if len(e) != 1 {
+			t.Fatalf("expected the code block to be embedded, got %q", data)
+		}
+
+		const dim = "\x1b[38;5;240m"
+		actual := e[0](20)
+		if len(actual) != 2 || strings.Contains(actual[0], dim) || !strings.HasPrefix(actual[1], dim) {
+			t.Fatalf("expected only the second line to be dimmed, got %q", actual)
+		}
+		if w := lipgloss.Width(actual[1]); w != 20 {
+			t.Errorf("expected the long line to be cut at 20 columns, got %d", w)
+		}
+	})
+
+	t.Run("background", func(t *testing.T) {
+		actual := b.render(lines, 0, CodeStyle{Background: "#102030", Padding: []int{1, 2}}, 40)
+		if len(actual) != 4 {
+			t.Fatalf("expected the code and a line of padding above and below, got %q", actual)
+		}
+
+		// Margin, padding, the longest line and padding.
+		const width = 2 + 2 + 9 + 2
+		bg := "48;2;16;32;48"
+		for i, line := range actual {
+			if w := lipgloss.Width(line); w != width {
+				t.Errorf("line %d: expected a width of %d, got %d: %q", i, width, w, line)
+			}
+			if !strings.Contains(line[2:], bg) || !strings.HasPrefix(line, "  ") {
+				t.Errorf("line %d: expected the background past the margin: %q", i, line)
+			}
+		}
+	})
+}
+
+func TestStepNavigation(t *testing.T) {
+	root := newDeck(t,
+		[2]string{"", "# A"},
+		[2]string{"", "```go {1|2|3}\na\nb\nc\n```"},
+		[2]string{"", "# C"},
+	)
+	var m tea.Model = New(root)
+
+	press := func(k string) (*Slide, int) {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		m, _ = m.Update(msg)
+		// Let transitions finish, navigation waits for them.
+		m.(model).slide.ActiveTransition = nil
+		slide := m.(model).slide
+		return slide, slide.step
+	}
+
+	steps := []struct {
+		key   string
+		slide *Slide
+		step  int
+	}{
+		{"l", root.Next, 0},
+		{"l", root.Next, 1},
+		{"l", root.Next, 2},
+		{"l", root.Next.Next, 0},
+		{"h", root.Next, 2},
+		{"h", root.Next, 1},
+		{"h", root.Next, 0},
+		{"h", root, 0},
+	}
+	for i, s := range steps {
+		slide, step := press(s.key)
+		if slide != s.slide || step != s.step {
+			t.Fatalf("press %d (%s): expected %q at step %d, got %q at step %d", i+1, s.key, s.slide.Data, s.step, slide.Data, step)
+		}
+	}
+}
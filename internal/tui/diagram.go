@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/museslabs/kyma/internal/diagram"
+)
+
+// parseDiagrams replaces the mermaid and diagram fenced blocks in data with
+// embeds drawing them.
+func parseDiagrams(data string, e *embeds, theme ansi.StyleConfig) string {
+	style := diagramStyle(theme)
+	return replaceFences(data, func(info string, body []string) (string, bool) {
+		lang, _, _ := strings.Cut(info, " ")
+		if lang != "mermaid" && lang != "diagram" {
+			return "", false
+		}
+
+		src := strings.Join(body, "\n")
+		return e.add(func(width int) []string {
+			out, err := diagram.Render(src, width, style)
+			if err != nil {
+				return embedError("Diagram", err)
+			}
+			return out
+		}), true
+	})
+}
+
+// diagramStyle colors nodes like the headings of the theme and edges like
+// its text.
+func diagramStyle(theme ansi.StyleConfig) diagram.Style {
+	node := lipgloss.NewStyle()
+	for _, c := range []*string{theme.H1.BackgroundColor, theme.Heading.Color, theme.Document.Color} {
+		if c != nil {
+			node = node.Foreground(lipgloss.Color(*c))
+			break
+		}
+	}
+
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color("245")) // Gray
+	if c := theme.Document.Color; c != nil {
+		text = text.Foreground(lipgloss.Color(*c))
+	}
+
+	return diagram.Style{Node: node, Edge: text, Label: text}
+}
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
+
+	"github.com/museslabs/kyma/internal/markdown"
+)
+
+// placeholderFormat is the text standing in for an embed while glamour
+// renders the slide.
+const placeholderFormat = "KYMAEMBED%dX"
+
+// embed renders content that kyma draws itself, like big titles or
+// diagrams, given the width available to it.
+type embed func(width int) []string
+
+// embeds collects the embeds of a slide, which are swapped for placeholders
+// before glamour renders the slide and swapped back in afterwards.
+type embeds []embed
+
+// add registers an embed and returns the markdown to put in its place.
+// Blank lines around the placeholder keep it in a paragraph of its own.
+func (e *embeds) add(render embed) string {
+	*e = append(*e, render)
+	return "\n" + fmt.Sprintf(placeholderFormat, len(*e)-1) + "\n"
+}
+
+// render swaps the placeholders in the rendered slide for their embeds,
+// indented like the placeholder. Width is the width of the slide content.
+func (e embeds) render(out string, width int) string {
+	if len(e) == 0 {
+		return out
+	}
+
+	lines := strings.Split(out, "\n")
+	for i, render := range e {
+		placeholder := fmt.Sprintf(placeholderFormat, i)
+		for j, line := range lines {
+			margin := strings.Index(xansi.Strip(line), placeholder)
+			if margin < 0 {
+				continue
+			}
+
+			content := render(width - margin)
+			indent := strings.Repeat(" ", margin)
+			for k := range content {
+				content[k] = indent + content[k]
+			}
+			lines[j] = strings.Join(content, "\n")
+			break
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// embedError renders the error an embed failed with in place of its content.
+func embedError(what string, err error) []string {
+	return []string{lipgloss.NewStyle().
+		Foreground(lipgloss.Color("9")). // Red
+		Render(what + " error: " + err.Error())}
+}
+
+// replaceFences replaces the fenced code blocks of data for which replace
+// returns true with the markdown it returns. Replace gets the info string of
+// the fence and the lines of the block.
+func replaceFences(data string, replace func(info string, body []string) (string, bool)) string {
+	lines := strings.Split(data, "\n")
+
+	var (
+		out    []string
+		fences markdown.Fences
+		open   int
+		info   string
+	)
+	for i, line := range lines {
+		switch fences.Next(line) {
+		case markdown.Text:
+			out = append(out, line)
+		case markdown.Open:
+			open, info = i, fences.Info()
+		case markdown.Close:
+			if replacement, ok := replace(info, lines[open+1:i]); ok {
+				out = append(out, replacement)
+			} else {
+				out = append(out, lines[open:i+1]...)
+			}
+		}
+	}
+	if fences.Inside() {
+		out = append(out, lines[open:]...)
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// mapProse applies f to the lines of data outside of fenced code blocks.
+func mapProse(data string, f func(line string) string) string {
+	lines := strings.Split(data, "\n")
+
+	var fences markdown.Fences
+	for i, line := range lines {
+		if fences.Next(line) == markdown.Text {
+			lines[i] = f(line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type timerTickMsg time.Time
+
+func tickTimer() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return timerTickMsg(t)
+	})
+}
+
+// position returns the 1-based index of the slide in its deck and the total
+// number of slides.
+func (s *Slide) position() (current, total int) {
+	for slide := s; slide != nil; slide = slide.Prev {
+		current++
+	}
+	total = current - 1
+	for slide := s; slide != nil; slide = slide.Next {
+		total++
+	}
+	return current, total
+}
+
+func progressBar(width, current, total int, style TextStyle) string {
+	if width <= 0 || total <= 0 {
+		return ""
+	}
+
+	color := "#9999CC" // Blueish
+	if style.Foreground != "" {
+		color = style.Foreground
+	}
+
+	filled := width * current / total
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(strings.Repeat("━", filled)) +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render(strings.Repeat("─", width-filled))
+}
+
+func timerView(elapsed, target time.Duration, style TextStyle) string {
+	text := formatDuration(elapsed)
+	if target <= 0 {
+		color := "8" // Gray
+		if style.Foreground != "" {
+			color = style.Foreground
+		}
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(text)
+	}
+
+	text += " / " + formatDuration(target)
+
+	color := lipgloss.Color("10") // Green
+	switch {
+	case elapsed >= target:
+		color = lipgloss.Color("9") // Red
+	case elapsed >= target*3/4:
+		color = lipgloss.Color("11") // Yellow
+	}
+
+	return lipgloss.NewStyle().Foreground(color).Render(text)
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", m, s)
+}
+
+func (m model) footerVisible() bool {
+	return m.showProgress || m.showTimer
+}
+
+func (m model) footer() string {
+	style := m.slide.Properties.Style.Footer
+
+	var timer string
+	if m.showTimer {
+		timer = timerView(time.Since(m.start), m.timerTarget, style)
+	}
+
+	var footer string
+	if !m.showProgress {
+		footer = lipgloss.PlaceHorizontal(m.width, lipgloss.Right, timer)
+	} else {
+		barWidth := m.width
+		if timer != "" {
+			barWidth -= lipgloss.Width(timer) + 1
+		}
+
+		current, total := m.slide.position()
+		footer = progressBar(barWidth, current, total, style)
+		if timer != "" {
+			footer += " " + timer
+		}
+	}
+
+	if style.Background != "" {
+		footer = fillBackground(footer, style.Background)
+		footer = lipgloss.NewStyle().Background(lipgloss.Color(style.Background)).Render(footer)
+	}
+	return footer
+}
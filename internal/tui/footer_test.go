@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTimerProperty(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    string
+		expected time.Duration
+		err      string
+	}{
+		{name: "minutes", input: "timer: 20m", expected: 20 * time.Minute},
+		{name: "quoted", input: `timer: "1h30m"`, expected: 90 * time.Minute},
+		{name: "missing", input: "progress: true"},
+		{name: "bare number", input: "timer: 20", err: "invalid timer: 20, expected a duration with a unit like 20m"},
+		{name: "negative", input: "timer: -5m", err: "invalid timer: -5m, expected a duration with a unit like 20m"},
+	}
+
+	for _, tc := range tt {
+		for _, strict := range []bool{false, true} {
+			p, err := NewProperties(tc.input, ParseOptions{Strict: strict})
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Errorf("%s (strict %t): expected error %q, got %v", tc.name, strict, tc.err, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("%s (strict %t): %v", tc.name, strict, err)
+				continue
+			}
+			if p.Timer != tc.expected {
+				t.Errorf("%s (strict %t): expected %s, got %s", tc.name, strict, tc.expected, p.Timer)
+			}
+		}
+	}
+}
+
+func TestTimerTicks(t *testing.T) {
+	slide := func(properties string) *Slide {
+		p, err := NewProperties(properties, ParseOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Slide{Properties: p}
+	}
+
+	m := New(slide(""))
+	if m.ticking {
+		t.Fatal("expected no ticks without a timer")
+	}
+
+	updated, cmd := m.Update(timerTickMsg{})
+	if cmd != nil {
+		t.Error("expected a stray tick not to schedule another one")
+	}
+
+	toggle := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")}
+	updated, cmd = updated.Update(toggle)
+	if m = updated.(model); cmd == nil || !m.ticking {
+		t.Error("expected showing the timer to start ticking")
+	}
+	updated, _ = m.Update(toggle)
+	if _, cmd = updated.Update(toggle); cmd != nil {
+		t.Error("expected a single tick to be scheduled")
+	}
+
+	m = New(slide("timer: 20m"))
+	if _, cmd := m.Update(timerTickMsg{}); cmd == nil {
+		t.Error("expected a shown countdown to keep ticking")
+	}
+	m.showTimer = false
+	if _, cmd := m.Update(timerTickMsg{}); cmd != nil {
+		t.Error("expected a hidden countdown to stop ticking")
+	}
+}
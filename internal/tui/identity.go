@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/museslabs/kyma/internal/markdown"
+)
+
+// identify assigns every slide in the deck an identity that survives
+// reloads: the id from its front matter, or else a fingerprint of its first
+// heading or, failing that, its content. Duplicates are numbered in order.
+func identify(root *Slide) {
+	seen := make(map[string]int)
+	for slide := root; slide != nil; slide = slide.Next {
+		id := fingerprint(slide)
+		seen[id]++
+		if n := seen[id]; n > 1 {
+			id = fmt.Sprintf("%s#%d", id, n)
+		}
+		slide.id = id
+	}
+}
+
+func fingerprint(s *Slide) string {
+	if s.Properties.ID != "" {
+		return "id:" + s.Properties.ID
+	}
+	if heading := firstHeading(s.Data); heading != "" {
+		return "heading:" + heading
+	}
+
+	sum := sha256.Sum256([]byte(strings.TrimSpace(s.Data)))
+	return "content:" + hex.EncodeToString(sum[:8])
+}
+
+func firstHeading(data string) string {
+	var fences markdown.Fences
+	for _, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if fences.Next(line) != markdown.Text || len(line)-len(trimmed) > 3 || !strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		heading := strings.TrimPrefix(strings.TrimLeft(trimmed, "#"), "!")
+		if heading == "" || heading[0] == ' ' || heading[0] == '\t' {
+			return strings.TrimSpace(heading)
+		}
+	}
+	return ""
+}
+
+// find returns the slide with the given identity, or nil.
+func find(root *Slide, id string) *Slide {
+	for slide := root; slide != nil; slide = slide.Next {
+		if slide.id == id {
+			return slide
+		}
+	}
+	return nil
+}
+
+// firstChanged returns the first slide of the new deck that was added or
+// whose content or front matter differs from the old deck, or nil when
+// nothing changed.
+func firstChanged(oldRoot, newRoot *Slide) *Slide {
+	for slide := newRoot; slide != nil; slide = slide.Next {
+		old := find(oldRoot, slide.id)
+		if old == nil || old.Data != slide.Data || !sameProperties(old.Properties, slide.Properties) {
+			return slide
+		}
+	}
+	return nil
+}
+
+// sameProperties reports whether two slides were given the same front
+// matter. Transitions carry their animation state, so only their names are
+// compared.
+func sameProperties(a, b Properties) bool {
+	if (a.Transition == nil) != (b.Transition == nil) ||
+		a.Transition != nil && a.Transition.Name() != b.Transition.Name() {
+		return false
+	}
+	a.Transition, b.Transition = nil, nil
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+// newDeck links slides, given as front matter and content pairs, into an
+// identified deck.
+func newDeck(t *testing.T, slides ...[2]string) *Slide {
+	t.Helper()
+
+	var root, prev *Slide
+	for _, s := range slides {
+		p, err := NewProperties(s[0], ParseOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		slide := &Slide{Data: s[1], Properties: p, Prev: prev}
+		if prev == nil {
+			root = slide
+		} else {
+			prev.Next = slide
+		}
+		prev = slide
+	}
+	identify(root)
+	return root
+}
+
+func TestIdentify(t *testing.T) {
+	tt := []struct {
+		name     string
+		slides   [][2]string
+		expected []string
+	}{
+		{
+			name:     "front matter id",
+			slides:   [][2]string{{"id: intro", "# Welcome"}},
+			expected: []string{"id:intro"},
+		},
+		{
+			name:     "first heading",
+			slides:   [][2]string{{"", "text\n\n  ## Agenda\n\n# Later"}},
+			expected: []string{"heading:Agenda"},
+		},
+		{
+			name:     "big title",
+			slides:   [][2]string{{"", "#! Kyma"}},
+			expected: []string{"heading:Kyma"},
+		},
+		{
+			name:     "heading in code",
+			slides:   [][2]string{{"", "```sh\n# comment\n```\n# Title"}},
+			expected: []string{"heading:Title"},
+		},
+		{
+			name:     "heading after a longer fence",
+			slides:   [][2]string{{"", "````md\n```\n# Inside\n```\n````\n# Title"}},
+			expected: []string{"heading:Title"},
+		},
+		{
+			name:     "not a heading",
+			slides:   [][2]string{{"", "#hashtag"}, {"", "    # indented code"}},
+			expected: []string{"content:", "content:"},
+		},
+		{
+			name:     "duplicates",
+			slides:   [][2]string{{"", "# Demo"}, {"", "# Demo"}, {"", "# Other"}, {"", "# Demo"}},
+			expected: []string{"heading:Demo", "heading:Demo#2", "heading:Other", "heading:Demo#3"},
+		},
+		{
+			name:     "duplicate content",
+			slides:   [][2]string{{"", "text"}, {"", "text\n"}},
+			expected: []string{"content:", "content:#2"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var i int
+			for slide := newDeck(t, tc.slides...); slide != nil; slide = slide.Next {
+				expected := tc.expected[i]
+				if prefix, suffix, ok := strings.Cut(expected, "content:"); ok && prefix == "" {
+					// Content fingerprints are hashes, only check the kind.
+					if !strings.HasPrefix(slide.id, "content:") || !strings.HasSuffix(slide.id, suffix) {
+						t.Errorf("slide %d: expected a content id ending in %q, got %q", i+1, suffix, slide.id)
+					}
+				} else if slide.id != expected {
+					t.Errorf("slide %d: expected id %q, got %q", i+1, expected, slide.id)
+				}
+				i++
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := newDeck(t, [2]string{"", "# A"}, [2]string{"", "# B"}, [2]string{"", "# A"})
+
+	if slide := find(root, "heading:A#2"); slide == nil || slide != root.Next.Next {
+		t.Error("expected the second A")
+	}
+	if slide := find(root, "heading:C"); slide != nil {
+		t.Errorf("expected no slide, got %q", slide.Data)
+	}
+}
+
+func TestReloadKeepsSlide(t *testing.T) {
+	base := [][2]string{{"", "# A"}, {"", "# B\n\n```go {1|2}\na\nb\n```"}, {"", "# C"}}
+
+	tt := []struct {
+		name     string
+		slides   [][2]string
+		expected string
+		step     int
+	}{
+		{
+			name:     "inserted above",
+			slides:   [][2]string{{"", "# New"}, base[0], base[1], base[2]},
+			expected: "# B",
+			step:     1,
+		},
+		{
+			name:     "removed above",
+			slides:   [][2]string{base[1], base[2]},
+			expected: "# B",
+			step:     1,
+		},
+		{
+			name:     "edited",
+			slides:   [][2]string{base[0], {"", "# B\n\nmore"}, base[2]},
+			expected: "# B\n\nmore",
+		},
+		{
+			name:     "removed",
+			slides:   [][2]string{base[0], base[2]},
+			expected: "# C",
+		},
+		{
+			name:     "removed at the end",
+			slides:   [][2]string{base[0]},
+			expected: "# A",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			m := New(newDeck(t, base...))
+			m.slide = m.slide.Next
+			m.slide.step = 1
+
+			updated, _ := m.Update(UpdateSlidesMsg{NewRoot: newDeck(t, tc.slides...)})
+			slide := updated.(model).slide
+			if !strings.HasPrefix(slide.Data, tc.expected) {
+				t.Errorf("expected to be on %q, got %q", tc.expected, slide.Data)
+			}
+			if slide.step != tc.step {
+				t.Errorf("expected step %d, got %d", tc.step, slide.step)
+			}
+		})
+	}
+}
+
+func TestFirstChanged(t *testing.T) {
+	base := [][2]string{
+		{"", "# One"},
+		{"style:\n  border: rounded\n  padding: 1 2", "# Two"},
+		{"transition: swipeLeft", "# Three"},
+	}
+
+	tt := []struct {
+		name     string
+		edit     func(slides [][2]string)
+		expected string
+	}{
+		{name: "unchanged", edit: func([][2]string) {}},
+		{name: "content", edit: func(s [][2]string) { s[2][1] = "# Three\n\nmore" }, expected: "# Three\n\nmore"},
+		{name: "style", edit: func(s [][2]string) { s[1][0] = "style:\n  border: rounded\n  padding: 2" }, expected: "# Two"},
+		{name: "transition", edit: func(s [][2]string) { s[2][0] = "transition: flip" }, expected: "# Three"},
+		{name: "added front matter", edit: func(s [][2]string) { s[0][0] = "progress: true" }, expected: "# One"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			edited := append([][2]string(nil), base...)
+			tc.edit(edited)
+
+			changed := firstChanged(newDeck(t, base...), newDeck(t, edited...))
+			switch {
+			case tc.expected == "" && changed != nil:
+				t.Errorf("expected no change, got %q", changed.Data)
+			case tc.expected != "" && (changed == nil || changed.Data != tc.expected):
+				t.Errorf("expected %q, got %v", tc.expected, changed)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	xansi "github.com/charmbracelet/x/ansi"
+
+	"github.com/museslabs/kyma/internal/markdown"
+)
+
+// Links are marked for glamour with sequences it takes for zero width escape
+// sequences and replaced with OSC 8 hyperlinks after rendering. They can't
+// hold a [ which markdown would read as a bracket.
+const (
+	linkStartFormat = "\x1b%dU"
+	linkEnd         = "\x1bV"
+	// linkAnchor is the destination of marked links. Glamour doesn't print
+	// destinations that are only an anchor after the link text.
+	linkAnchor = "#kyma"
+)
+
+var (
+	linkRe = regexp.MustCompile(`!?\[([^\]]*)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)` +
+		`|<(https?://[^>\s]+)>` +
+		`|https?://[^\s<>()\[\]]*[^\s<>()\[\].,;:!?'"*_~]`)
+	linkMarkerRe = regexp.MustCompile("\x1b(?:(\\d+)U|V)")
+)
+
+// links collects the destinations of the links of a slide.
+type links []string
+
+// parseLinks marks the links in data outside of code and tables, replacing
+// the destination glamour prints after the link text with a hyperlink on the
+// text itself.
+func parseLinks(data string, l *links) string {
+	return mapProse(data, func(line string) string {
+		if strings.HasPrefix(strings.TrimSpace(line), "|") {
+			// Tables measure their cells without regard for the markers.
+			return line
+		}
+		return markdown.OutsideCodeSpans(line, func(s string) string {
+			return linkRe.ReplaceAllStringFunc(s, func(m string) string {
+				parts := linkRe.FindStringSubmatch(m)
+				text, url := parts[1], parts[2]
+				switch {
+				case strings.HasPrefix(m, "!"), strings.HasPrefix(url, "#"):
+					// Images and anchors.
+					return m
+				case parts[3] != "":
+					text, url = parts[3], parts[3]
+				case url == "":
+					text, url = m, m
+				}
+
+				*l = append(*l, url)
+				return "[" + fmt.Sprintf(linkStartFormat, len(*l)-1) + text + linkEnd + "](" + linkAnchor + ")"
+			})
+		})
+	})
+}
+
+// render replaces the link markers in the rendered slide with hyperlinks.
+// Links are closed at the end of every line and reopened on the next, so
+// nothing drawn around the lines becomes part of them.
+func (l links) render(out string) string {
+	if len(l) == 0 {
+		return out
+	}
+
+	lines := strings.Split(out, "\n")
+	open := ""
+	for i, line := range lines {
+		reopen := open
+		line = linkMarkerRe.ReplaceAllStringFunc(line, func(m string) string {
+			index := linkMarkerRe.FindStringSubmatch(m)[1]
+			if index == "" {
+				open = ""
+				return xansi.ResetHyperlink()
+			}
+			n, _ := strconv.Atoi(index)
+			open = l[n]
+			return xansi.SetHyperlink(open)
+		})
+
+		if reopen != "" {
+			at := indentEnd(line)
+			line = line[:at] + xansi.SetHyperlink(reopen) + line[at:]
+		}
+		if open != "" {
+			line += xansi.ResetHyperlink()
+		}
+		lines[i] = line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// indentEnd returns the index of the first visible character of line past
+// its indentation, skipping the SGR sequences styling it.
+func indentEnd(line string) int {
+	i := 0
+	for i < len(line) {
+		switch {
+		case line[i] == ' ':
+			i++
+		case strings.HasPrefix(line[i:], "\x1b["):
+			end := strings.IndexFunc(line[i+2:], func(r rune) bool { return r >= 0x40 && r <= 0x7e })
+			if end < 0 {
+				return i
+			}
+			i += end + 3
+		default:
+			return i
+		}
+	}
+	return i
+}
@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/glamour/styles"
+	xansi "github.com/charmbracelet/x/ansi"
+)
+
+func TestParseLinks(t *testing.T) {
+	mark := func(i, text string) string {
+		return "[\x1b" + i + "U" + text + "\x1bV](#kyma)"
+	}
+
+	tt := []struct {
+		name     string
+		input    string
+		expected string
+		links    links
+	}{
+		{
+			name:     "inline",
+			input:    "see [docs](https://example.com) now",
+			expected: "see " + mark("0", "docs") + " now",
+			links:    links{"https://example.com"},
+		},
+		{
+			name:     "title and angle brackets",
+			input:    `[docs](<https://example.com> "Docs")`,
+			expected: mark("0", "docs"),
+			links:    links{"https://example.com"},
+		},
+		{
+			name:     "autolink",
+			input:    "<https://example.com>",
+			expected: mark("0", "https://example.com"),
+			links:    links{"https://example.com"},
+		},
+		{
+			name:     "bare url",
+			input:    "go to https://example.com/a_b.",
+			expected: "go to " + mark("0", "https://example.com/a_b") + ".",
+			links:    links{"https://example.com/a_b"},
+		},
+		{
+			name:     "several",
+			input:    "[a](https://a.io) and [b](https://b.io)",
+			expected: mark("0", "a") + " and " + mark("1", "b"),
+			links:    links{"https://a.io", "https://b.io"},
+		},
+		{
+			name:     "code span",
+			input:    "`[a](https://a.io)` and `https://b.io` but [c](https://c.io)",
+			expected: "`[a](https://a.io)` and `https://b.io` but " + mark("0", "c"),
+			links:    links{"https://c.io"},
+		},
+		{
+			name:     "fenced code",
+			input:    "```\n[a](https://a.io)\n```\nhttps://b.io",
+			expected: "```\n[a](https://a.io)\n```\n" + mark("0", "https://b.io"),
+			links:    links{"https://b.io"},
+		},
+		{
+			name:     "image",
+			input:    "![logo](https://example.com/logo.png)",
+			expected: "![logo](https://example.com/logo.png)",
+		},
+		{
+			name:     "anchor",
+			input:    "[top](#top)",
+			expected: "[top](#top)",
+		},
+		{
+			name:     "table",
+			input:    "| [a](https://a.io) |",
+			expected: "| [a](https://a.io) |",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var l links
+			actual := parseLinks(tc.input, &l)
+			if actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+			if !reflect.DeepEqual(l, tc.links) {
+				t.Errorf("expected links %q, got %q", tc.links, l)
+			}
+		})
+	}
+}
+
+func TestLinksRender(t *testing.T) {
+	var (
+		open  = xansi.SetHyperlink("https://example.com")
+		reset = xansi.ResetHyperlink()
+		l     = links{"https://example.com"}
+	)
+
+	tt := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "single line",
+			input:    "a \x1b0Ulink\x1bV b",
+			expected: "a " + open + "link" + reset + " b",
+		},
+		{
+			name:     "wrapped",
+			input:    "  a \x1b0Ulong\n  \x1b[1mtext\x1bV b\nc",
+			expected: "  a " + open + "long" + reset + "\n  \x1b[1m" + open + "text" + reset + " b\nc",
+		},
+		{
+			name:     "no markers",
+			input:    "a b",
+			expected: "a b",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := l.render(tc.input); actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLinksWrappedByGlamour(t *testing.T) {
+	var l links
+	data := parseLinks("Read [the very long documentation page](https://example.com/docs) today.", &l)
+	out, err := render(data, styles.NoTTYStyleConfig, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out = l.render(out)
+
+	// The link is closed at the end of the first line and reopened past the
+	// indentation of the second.
+	var (
+		open  = xansi.SetHyperlink("https://example.com/docs")
+		reset = xansi.ResetHyperlink()
+	)
+	expected := []string{
+		"",
+		"  Read " + open + "the very long\x1b[0m      " + reset,
+		"\x1b[0m  " + open + "documentation page" + reset + "      \x1b[0m",
+		"\x1b[0m  today.                  ",
+	}
+	if lines := strings.Split(out, "\n"); !reflect.DeepEqual(lines[:4], expected) {
+		t.Errorf("expected\n%q\ngot\n%q", expected, lines[:4])
+	}
+}
+
+func TestIndentEnd(t *testing.T) {
+	tt := []struct {
+		input    string
+		expected int
+	}{
+		{"text", 0},
+		{"  text", 2},
+		{"\x1b[0m  text", 6},
+		{"  \x1b[38;5;252m\x1b[1mtext", 17},
+		{"   ", 3},
+		{"\x1b[", 0},
+	}
+
+	for _, tc := range tt {
+		if actual := indentEnd(tc.input); actual != tc.expected {
+			t.Errorf("indentEnd(%q): expected %d, got %d", tc.input, tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/museslabs/kyma/internal/markdown"
+	"github.com/museslabs/kyma/internal/texmath"
+)
+
+// markdownEscaper escapes the characters of rendered math that markdown would
+// read as formatting.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "|", `\|`, "~", `\~`, "#", `\#`,
+)
+
+// parseMath renders the LaTeX math in data outside of code. Inline math,
+// written as $...$, is replaced with Unicode text and display math, written
+// as $$...$$ on lines of its own, with embeds laying it out over several
+// lines.
+func parseMath(data string, e *embeds) string {
+	var (
+		out     []string
+		fences  markdown.Fences
+		display []string
+		inMath  bool
+	)
+
+	for _, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if inMath {
+			display = append(display, strings.TrimSuffix(trimmed, "$$"))
+			if strings.HasSuffix(trimmed, "$$") {
+				out = append(out, e.add(displayMath(strings.Join(display, " "))))
+				inMath, display = false, nil
+			}
+			continue
+		}
+
+		if fences.Next(line) != markdown.Text {
+			out = append(out, line)
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "$$") {
+			src := strings.TrimPrefix(trimmed, "$$")
+			if len(src) >= 2 && strings.HasSuffix(src, "$$") {
+				out = append(out, e.add(displayMath(strings.TrimSuffix(src, "$$"))))
+				continue
+			}
+			inMath, display = true, []string{src}
+			continue
+		}
+
+		out = append(out, inlineMath(line))
+	}
+	if inMath {
+		// Unclosed, left as written.
+		out = append(out, "$$"+strings.Join(display, "\n"))
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// displayMath lays out math centered in the width of the slide.
+func displayMath(src string) embed {
+	return func(width int) []string {
+		lines, err := texmath.Display(src)
+		if err != nil {
+			return embedError("Math", err)
+		}
+
+		if w := runewidth.StringWidth(lines[0]); w < width {
+			indent := strings.Repeat(" ", (width-w)/2)
+			for i := range lines {
+				lines[i] = indent + lines[i]
+			}
+		}
+		return lines
+	}
+}
+
+// inlineMath replaces the $...$ spans of line outside of code spans with
+// their rendering. Like pandoc, the opening $ must be followed and the closing
+// $ preceded by a non-space character, and the closing $ must not be
+// followed by a digit, so prices are left alone, and \$ is a literal dollar
+// sign. Math that fails to render is left as written.
+func inlineMath(line string) string {
+	return markdown.OutsideCodeSpans(line, func(text string) string {
+		var b strings.Builder
+		for i := 0; i < len(text); {
+			switch c := text[i]; {
+			case c == '\\' && i+1 < len(text):
+				if text[i+1] == '$' {
+					// An escaped dollar sign is a literal one.
+					b.WriteByte('$')
+				} else {
+					b.WriteString(text[i : i+2])
+				}
+				i += 2
+			case c == '$':
+				end := closingDollar(text, i)
+				if end < 0 {
+					b.WriteByte(c)
+					i++
+					continue
+				}
+				s, err := texmath.Inline(text[i+1 : end])
+				if err != nil {
+					b.WriteString(text[i : end+1])
+				} else {
+					b.WriteString(markdownEscaper.Replace(s))
+				}
+				i = end + 1
+			default:
+				b.WriteByte(c)
+				i++
+			}
+		}
+		return b.String()
+	})
+}
+
+// closingDollar returns the index of the $ closing the inline math opened at
+// start, or -1.
+func closingDollar(line string, start int) int {
+	if start+1 >= len(line) || line[start+1] == ' ' || line[start+1] == '$' {
+		return -1
+	}
+	for j := start + 2; j < len(line); j++ {
+		if line[j] != '$' || line[j-1] == '\\' {
+			continue
+		}
+		if line[j-1] == ' ' || j+1 < len(line) && line[j+1] >= '0' && line[j+1] <= '9' {
+			return -1
+		}
+		return j
+	}
+	return -1
+}
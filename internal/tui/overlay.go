@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/museslabs/kyma/internal/skip"
+)
+
+// ReloadErrorMsg reports a failed reload, the current deck stays on screen
+// with the error shown on top of it.
+type ReloadErrorMsg struct {
+	Err error
+}
+
+func errorBanner(err error, width int) string {
+	bannerWidth := min(width-4, 80)
+	if bannerWidth <= 0 {
+		return ""
+	}
+
+	red := lipgloss.Color("9")
+	title := lipgloss.NewStyle().Bold(true).Foreground(red).Render("Error while updating")
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("Showing the last working version, press x to dismiss")
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(red).
+		Padding(0, 1).
+		Width(bannerWidth).
+		Render(title + "\n\n" + err.Error() + "\n\n" + hint)
+}
+
+// overlay draws fg on top of bg with its top left corner at column x and
+// line y.
+func overlay(bg, fg string, x, y int) string {
+	bgLines := strings.Split(bg, "\n")
+	fgLines := strings.Split(fg, "\n")
+
+	for i, fgLine := range fgLines {
+		row := y + i
+		if row < 0 || row >= len(bgLines) {
+			continue
+		}
+
+		bgLine := bgLines[row]
+		left := skip.Cut(bgLine, 0, x)
+		if padding := x - lipgloss.Width(left); padding > 0 {
+			left += strings.Repeat(" ", padding)
+		}
+		right := skip.Cut(bgLine, x+lipgloss.Width(fgLine), math.MaxInt)
+
+		bgLines[row] = left + fgLine + "\x1b[0m" + right
+	}
+
+	return strings.Join(bgLines, "\n")
+}
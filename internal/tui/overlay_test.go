@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var errLong = errors.New("open /home/presenter/decks/a-rather-long-directory-name/slides-with-a-long-name.md: no such file or directory")
+
+func TestErrorBanner(t *testing.T) {
+	tt := []struct {
+		width    int
+		expected int
+	}{
+		{width: 120, expected: 82},
+		{width: 60, expected: 58},
+		{width: 20, expected: 18},
+		{width: 4, expected: 0},
+	}
+
+	for _, tc := range tt {
+		banner := errorBanner(errLong, tc.width)
+		if actual := lipgloss.Width(banner); actual != tc.expected {
+			t.Errorf("width %d: expected a banner %d columns wide, got %d:\n%s", tc.width, tc.expected, actual, banner)
+		}
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	bg := "abcdef\nghijkl\nmnopqr"
+
+	tt := []struct {
+		name     string
+		fg       string
+		x, y     int
+		expected string
+	}{
+		{"inside", "XY", 2, 1, "abcdef\nghXY\x1b[0mkl\nmnopqr"},
+		{"past the bottom", "XY\nZW", 0, 2, "abcdef\nghijkl\nXY\x1b[0mopqr"},
+		{"past a short line", "XY", 8, 0, "abcdef  XY\x1b[0m\nghijkl\nmnopqr"},
+		{"above", "XY", 0, -1, bg},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := overlay(bg, tc.fg, tc.x, tc.y); actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestErrorOverlay(t *testing.T) {
+	for _, size := range []tea.WindowSizeMsg{{Width: 100, Height: 30}, {Width: 30, Height: 8}} {
+		var m tea.Model = New(newDeck(t, [2]string{"", "# Slide"}))
+		m, _ = m.Update(size)
+		before := strings.Split(m.View(), "\n")
+		m, _ = m.Update(ReloadErrorMsg{Err: errLong})
+		after := strings.Split(m.View(), "\n")
+
+		if len(after) != len(before) {
+			t.Errorf("%dx%d: expected the banner to keep the view %d lines high, got %d", size.Width, size.Height, len(before), len(after))
+		}
+		for i, line := range after {
+			if w := lipgloss.Width(line); w > size.Width {
+				t.Errorf("%dx%d: line %d is %d columns wide: %q", size.Width, size.Height, i, w, line)
+			}
+		}
+		if !strings.Contains(m.View(), "Error while updating") {
+			t.Errorf("%dx%d: expected the banner to be shown", size.Width, size.Height)
+		}
+	}
+}
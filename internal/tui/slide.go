@@ -1,12 +1,19 @@
 package tui
 
 import (
+	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/glamour/styles"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/goccy/go-yaml"
+	"github.com/muesli/termenv"
+
 	"github.com/museslabs/kyma/internal/tui/transitions"
 )
 
@@ -19,6 +26,8 @@ type Slide struct {
 	Properties       Properties
 
 	preRenderedFrame string
+	id               string
+	step             int
 }
 
 type UpdateSlidesMsg struct {
@@ -45,13 +54,23 @@ func (s Slide) View() string {
 func (s Slide) view() string {
 	var b strings.Builder
 
-	themeName := "dark"
+	theme := styles.DarkStyleConfig
 
 	if s.Style.Theme.Name != "" {
-		themeName = s.Style.Theme.Name
+		theme = s.Style.Theme.Style
 	}
 
-	out, err := glamour.Render(s.Data, themeName)
+	var (
+		e embeds
+		l links
+	)
+	data := parseDiagrams(s.Data, &e, theme)
+	data = parseCharts(data, &e, theme)
+	data = parseMath(data, &e)
+	data = parseCode(data, &e, s.step, s.Style.Code, theme)
+	data = parseBigTitles(data, s.Style.BigTitle, &e, theme)
+	data = parseLinks(data, &l)
+	out, err := render(data, theme, s.contentWidth())
 	if err != nil {
 		b.WriteString("\n\n" + lipgloss.NewStyle().
 			Foreground(lipgloss.Color("9")). // Red
@@ -59,6 +78,13 @@ func (s Slide) view() string {
 		return b.String()
 	}
 
+	out = l.render(out)
+	out = e.render(out, s.contentWidth())
+
+	if s.Style.Background != "" {
+		out = fillBackground(out, s.Style.Background)
+	}
+
 	if s.ActiveTransition != nil && s.ActiveTransition.Animating() {
 		direction := s.ActiveTransition.Direction()
 		if direction == transitions.Backwards {
@@ -72,33 +98,115 @@ func (s Slide) view() string {
 	return b.String()
 }
 
+// contentWidth returns the width available to the content of the slide.
+func (s Slide) contentWidth() int {
+	style := s.Style.LipGlossStyle
+	if style.GetWidth() == 0 {
+		return 80
+	}
+	return style.GetWidth() - style.GetHorizontalPadding()
+}
+
+// steps returns the number of code highlight steps of the slide.
+func (s *Slide) steps() int {
+	return stepCount(parseCodeBlocks(s.Data))
+}
+
+// render renders the markdown in data, wrapping it at the glamour default of
+// 80 columns or narrower when the slide is narrower than that.
+func render(data string, theme ansi.StyleConfig, width int) (string, error) {
+	r, err := glamour.NewTermRenderer(glamour.WithStyles(theme), glamour.WithWordWrap(min(width, 80)))
+	if err != nil {
+		return "", err
+	}
+	return r.Render(data)
+}
+
+// fillBackground re-applies the background color after every reset sequence
+// emitted by glamour, so that the background covers the whole slide instead
+// of only the styled text.
+func fillBackground(s, color string) string {
+	seq := termenv.CSI + lipgloss.ColorProfile().Color(color).Sequence(true) + "m"
+	s = strings.ReplaceAll(s, termenv.CSI+termenv.ResetSeq+"m", termenv.CSI+termenv.ResetSeq+"m"+seq)
+	return seq + strings.ReplaceAll(s, "\n", "\n"+seq)
+}
+
 type Properties struct {
+	ID         string                 `yaml:"id"`
 	Style      StyleConfig            `yaml:"style"`
 	Transition transitions.Transition `yaml:"transition"`
+	Progress   bool                   `yaml:"progress"`
+	Timer      time.Duration          `yaml:"timer"`
 }
 
-func (p *Properties) UnmarshalYAML(bytes []byte) error {
+func (p *Properties) UnmarshalYAML(ctx context.Context, bytes []byte) error {
 	aux := struct {
+		ID         string      `yaml:"id"`
 		Style      StyleConfig `yaml:"style"`
 		Transition string      `yaml:"transition"`
+		Progress   bool        `yaml:"progress"`
+		Timer      string      `yaml:"timer"`
 	}{}
+	// Front matter without a style key keeps the default border sides.
+	aux.Style.BorderSides = allBorderSides
 
-	if err := yaml.Unmarshal(bytes, &aux); err != nil {
+	if err := yaml.UnmarshalContext(ctx, bytes, &aux, decodeOptions(ctx)...); err != nil {
 		return err
 	}
+	if aux.Transition == "" {
+		aux.Transition = aux.Style.transition
+	}
 	p.Transition = transitions.Get(aux.Transition, Fps)
+	p.ID = aux.ID
 	p.Style = aux.Style
+	p.Progress = aux.Progress
+	if aux.Timer != "" {
+		timer, err := time.ParseDuration(aux.Timer)
+		if err != nil || timer < 0 {
+			return fmt.Errorf("invalid timer: %s, expected a duration with a unit like 20m", aux.Timer)
+		}
+		p.Timer = timer
+	}
+
+	return nil
+}
 
+type parseOptionsKey struct{}
+
+// ParseOptions configure how slide properties are parsed.
+type ParseOptions struct {
+	// Strict rejects unknown keys in the front matter instead of ignoring
+	// them.
+	Strict bool
+	// BaseDir is the directory relative paths in the front matter are
+	// resolved against, usually the directory of the deck.
+	BaseDir string
+}
+
+func parseOptions(ctx context.Context) ParseOptions {
+	opts, _ := ctx.Value(parseOptionsKey{}).(ParseOptions)
+	return opts
+}
+
+func decodeOptions(ctx context.Context) []yaml.DecodeOption {
+	if parseOptions(ctx).Strict {
+		return []yaml.DecodeOption{yaml.Strict()}
+	}
 	return nil
 }
 
-func NewProperties(properties string) (Properties, error) {
+func NewProperties(properties string, opts ParseOptions) (Properties, error) {
 	if properties == "" {
-		return Properties{Transition: transitions.Get("default", Fps)}, nil
+		return Properties{
+			Style:      StyleConfig{BorderSides: allBorderSides},
+			Transition: transitions.Get("default", Fps),
+		}, nil
 	}
 
+	ctx := context.WithValue(context.Background(), parseOptionsKey{}, opts)
+
 	var p Properties
-	if err := yaml.Unmarshal([]byte(properties), &p); err != nil {
+	if err := yaml.UnmarshalContext(ctx, []byte(properties), &p); err != nil {
 		return Properties{}, err
 	}
 
@@ -1,20 +1,34 @@
 package tui
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
+	chromastyles "github.com/alecthomas/chroma/v2/styles"
 	"github.com/charmbracelet/glamour/ansi"
 	"github.com/charmbracelet/glamour/styles"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/goccy/go-yaml"
+
+	"github.com/museslabs/kyma/internal/bigtext"
+	"github.com/museslabs/kyma/internal/paths"
 )
 
 type SlideStyle struct {
 	LipGlossStyle lipgloss.Style
 	Theme         GlamourTheme
+	Background    string
+	Code          CodeStyle
+	BigTitle      string
 }
 type GlamourTheme struct {
 	Style ansi.StyleConfig
@@ -25,20 +39,55 @@ type StyleConfig struct {
 	Layout      lipgloss.Style  `yaml:"layout"`
 	Border      lipgloss.Border `yaml:"border"`
 	BorderColor string          `yaml:"border_color"`
+	BorderSides BorderSides     `yaml:"border_sides"`
+	Foreground  string          `yaml:"foreground"`
+	Background  string          `yaml:"background"`
+	Padding     []int           `yaml:"padding"`
+	Margin      []int           `yaml:"margin"`
+	TitleBold   *bool           `yaml:"title_bold"`
+	TitleItalic *bool           `yaml:"title_italic"`
 	Theme       GlamourTheme    `yaml:"theme"`
+	CodeTheme   string          `yaml:"code_theme"`
+	BigTitle    string          `yaml:"big_title"`
+	Code        CodeStyle       `yaml:"-"`
+	Footer      TextStyle       `yaml:"-"`
+	ThemeFiles  []string        `yaml:"-"`
+
+	transition string
+}
+
+type BorderSides struct {
+	Top    bool
+	Right  bool
+	Bottom bool
+	Left   bool
 }
 
-func (s *StyleConfig) UnmarshalYAML(bytes []byte) error {
+var allBorderSides = BorderSides{Top: true, Right: true, Bottom: true, Left: true}
+
+func (s *StyleConfig) UnmarshalYAML(ctx context.Context, bytes []byte) error {
 	aux := struct {
-		Layout      string `yaml:"layout"`
-		Border      string `yaml:"border"`
-		BorderColor string `yaml:"border_color"`
-		Theme       string `yaml:"theme"`
+		Layout          string `yaml:"layout"`
+		Border          string `yaml:"border"`
+		BorderColor     string `yaml:"border_color"`
+		BorderSides     string `yaml:"border_sides"`
+		Foreground      string `yaml:"foreground"`
+		Background      string `yaml:"background"`
+		Padding         string `yaml:"padding"`
+		Margin          string `yaml:"margin"`
+		TitleBold       *bool  `yaml:"title_bold"`
+		TitleItalic     *bool  `yaml:"title_italic"`
+		Theme           string `yaml:"theme"`
+		CodeTheme       string `yaml:"code_theme"`
+		CodeLineNumbers bool   `yaml:"code_line_numbers"`
+		CodeBackground  string `yaml:"code_background"`
+		CodePadding     string `yaml:"code_padding"`
+		BigTitle        string `yaml:"big_title"`
 	}{}
 
 	var err error
 
-	if err = yaml.Unmarshal(bytes, &aux); err != nil {
+	if err = yaml.UnmarshalContext(ctx, bytes, &aux, decodeOptions(ctx)...); err != nil {
 		return err
 	}
 
@@ -47,9 +96,87 @@ func (s *StyleConfig) UnmarshalYAML(bytes []byte) error {
 		return err
 	}
 
+	theme, err := getTheme(aux.Theme, parseOptions(ctx))
+	if err != nil {
+		return err
+	}
+	if aux.Border == "" {
+		aux.Border = theme.Border
+	}
+	if aux.BorderColor == "" {
+		aux.BorderColor = theme.BorderColor
+	}
+	if aux.Foreground == "" {
+		aux.Foreground = theme.Foreground
+	}
+	if aux.Background == "" {
+		aux.Background = theme.Background
+	}
+
+	s.BorderSides, err = getBorderSides(aux.BorderSides)
+	if err != nil {
+		return err
+	}
+
+	if aux.BorderColor != "default" {
+		if err := validateColor("border_color", aux.BorderColor); err != nil {
+			return err
+		}
+	}
+	if err := validateColor("foreground", aux.Foreground); err != nil {
+		return err
+	}
+	if err := validateColor("background", aux.Background); err != nil {
+		return err
+	}
+	if err := validateColor("code_background", aux.CodeBackground); err != nil {
+		return err
+	}
+
+	if aux.BigTitle != "" && !bigtext.IsFont(aux.BigTitle) {
+		return fmt.Errorf(
+			"invalid big_title font: %s, expected one of %s",
+			aux.BigTitle,
+			strings.Join(bigtext.Fonts, ", "),
+		)
+	}
+
+	if aux.CodeTheme != "" {
+		if _, ok := chromastyles.Registry[strings.ToLower(aux.CodeTheme)]; !ok {
+			return fmt.Errorf("invalid code_theme: %s, expected a chroma style like monokai or dracula", aux.CodeTheme)
+		}
+	}
+
+	s.Padding, err = getSpacing("padding", aux.Padding)
+	if err != nil {
+		return err
+	}
+	s.Margin, err = getSpacing("margin", aux.Margin)
+	if err != nil {
+		return err
+	}
+	s.Code.Padding, err = getSpacing("code_padding", aux.CodePadding)
+	if err != nil {
+		return err
+	}
+	if len(s.Code.Padding) == 0 && aux.CodeBackground != "" {
+		s.Code.Padding = []int{0, 1}
+	}
+
 	s.Border = getBorder(aux.Border)
 	s.BorderColor = aux.BorderColor
-	s.Theme = getTheme(aux.Theme)
+	s.Foreground = aux.Foreground
+	s.Background = aux.Background
+	s.TitleBold = aux.TitleBold
+	s.TitleItalic = aux.TitleItalic
+	s.CodeTheme = strings.ToLower(aux.CodeTheme)
+	s.Code.LineNumbers = aux.CodeLineNumbers
+	s.Code.Background = aux.CodeBackground
+	s.BigTitle = aux.BigTitle
+	s.Theme = theme.Glamour
+	s.Footer = theme.Footer
+	s.ThemeFiles = theme.Files
+	s.transition = theme.Transition
 
 	return nil
 }
@@ -71,15 +198,133 @@ func (s StyleConfig) ApplyStyle(width, height int) SlideStyle {
 	}
 
 	style := s.Layout.
-		Border(s.Border).
+		Border(s.Border, s.BorderSides.Top, s.BorderSides.Right, s.BorderSides.Bottom, s.BorderSides.Left).
 		BorderForeground(lipgloss.Color(borderColor)).
-		Width(width - 4).
-		Height(height - 2)
+		Padding(s.Padding...).
+		Margin(s.Margin...)
+
+	// Keep the outer size of the slide constant regardless of margins and
+	// hidden border sides, transitions expect slides of equal size. Slides
+	// without a border at all keep the size they always had.
+	width -= 4 + style.GetHorizontalMargins()
+	height -= 2 + style.GetVerticalMargins()
+	if s.Border != (lipgloss.Border{}) {
+		if !s.BorderSides.Top {
+			height++
+		}
+		if !s.BorderSides.Bottom {
+			height++
+		}
+		if !s.BorderSides.Left {
+			width++
+		}
+		if !s.BorderSides.Right {
+			width++
+		}
+	}
+	style = style.Width(width).Height(height)
+
+	theme := s.Theme
+	if s.Foreground != "" {
+		style = style.Foreground(lipgloss.Color(s.Foreground))
+		theme.Style.Document.Color = &s.Foreground
+	}
+	if s.Background != "" {
+		style = style.Background(lipgloss.Color(s.Background)).
+			BorderBackground(lipgloss.Color(s.Background))
+		theme.Style.Document.BackgroundColor = &s.Background
+	}
+	if s.TitleBold != nil {
+		theme.Style.H1.Bold = s.TitleBold
+	}
+	if s.TitleItalic != nil {
+		theme.Style.H1.Italic = s.TitleItalic
+	}
+	if s.CodeTheme != "" {
+		// Glamour prefers its own chroma style over the theme when set.
+		theme.Style.CodeBlock.Theme = s.CodeTheme
+		theme.Style.CodeBlock.Chroma = nil
+	}
 
 	return SlideStyle{
 		LipGlossStyle: style,
-		Theme:         s.Theme,
+		Theme:         theme,
+		Background:    s.Background,
+		Code:          s.Code,
+		BigTitle:      s.BigTitle,
+	}
+}
+
+func getBorderSides(sides string) (BorderSides, error) {
+	sides = strings.TrimSpace(sides)
+	if sides == "" || sides == "all" {
+		return allBorderSides, nil
 	}
+
+	var b BorderSides
+	if sides == "none" {
+		return b, nil
+	}
+
+	for _, side := range strings.Split(sides, ",") {
+		switch strings.TrimSpace(side) {
+		case "top":
+			b.Top = true
+		case "right":
+			b.Right = true
+		case "bottom":
+			b.Bottom = true
+		case "left":
+			b.Left = true
+		default:
+			return b, fmt.Errorf("invalid border side: %s", strings.TrimSpace(side))
+		}
+	}
+
+	return b, nil
+}
+
+func getSpacing(name, spacing string) ([]int, error) {
+	fields := strings.FieldsFunc(spacing, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	if len(fields) > 4 {
+		return nil, fmt.Errorf("invalid %s configuration: %s, expected 1 to 4 values", name, spacing)
+	}
+
+	values := make([]int, 0, len(fields))
+	for _, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("invalid %s value: %s, expected a non-negative integer", name, f)
+		}
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+func validateColor(name, color string) error {
+	if color == "" {
+		return nil
+	}
+
+	if strings.HasPrefix(color, "#") {
+		hex := color[1:]
+		if len(hex) == 3 || len(hex) == 6 {
+			if _, err := strconv.ParseUint(hex, 16, 32); err == nil {
+				return nil
+			}
+		}
+	} else if n, err := strconv.Atoi(color); err == nil && n >= 0 && n <= 255 {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"invalid %s color: %q, expected a hex color like \"#FF0000\" or an ANSI color number between 0 and 255",
+		name,
+		color,
+	)
 }
 
 func getBorder(border string) lipgloss.Border {
@@ -152,21 +397,84 @@ func getLayoutPosition(p string) (lipgloss.Position, error) {
 	}
 }
 
-func getTheme(theme string) GlamourTheme {
-	style, ok := styles.DefaultStyles[theme]
+func getTheme(theme string, opts ParseOptions) (Theme, error) {
+	return loadTheme(theme, opts, nil)
+}
+
+// loadTheme loads a theme referenced from the theme files in chain, outermost
+// first. Names are looked up in the theme directories of the deck, skipping
+// the referring theme file, while paths are relative to it.
+func loadTheme(theme string, opts ParseOptions, chain []string) (Theme, error) {
+	baseDir := opts.BaseDir
+	var referrer string
+	if len(chain) > 0 {
+		referrer = chain[len(chain)-1]
+	}
+
+	switch theme {
+	case "":
+		return Theme{Name: "dark", Glamour: GlamourTheme{Style: styles.DarkStyleConfig, Name: "dark"}}, nil
+	case styles.AutoStyle:
+		theme = styles.DarkStyle
+		if !lipgloss.HasDarkBackground() {
+			theme = styles.LightStyle
+		}
+	case "tokyonight":
+		theme = styles.TokyoNightStyle
+	}
+
+	if style, ok := styles.DefaultStyles[theme]; ok {
+		return Theme{Name: theme, Glamour: GlamourTheme{Style: *style, Name: theme}}, nil
+	}
+
+	path, ok := findTheme(theme, baseDir, referrer)
 	if !ok {
-		jsonBytes, err := os.ReadFile(theme)
-		if err != nil {
-			return GlamourTheme{Style: styles.DarkStyleConfig, Name: "dark"}
+		dir := baseDir
+		if referrer != "" {
+			dir = filepath.Dir(referrer)
+		}
+		path = paths.Resolve(dir, theme)
+	}
+	if slices.Contains(chain, absPath(path)) {
+		return Theme{}, &ThemeError{
+			Path: referrer,
+			Err:  fmt.Errorf("glamour references form a cycle: %s", strings.Join(append(chain, absPath(path)), " -> ")),
 		}
+	}
 
-		var customStyle ansi.StyleConfig
-		if err := json.Unmarshal(jsonBytes, &customStyle); err != nil {
-			return GlamourTheme{Style: styles.DarkStyleConfig, Name: "dark"}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Theme{}, &ThemeNotFoundError{Name: theme, Dirs: themeDirs(baseDir)}
 		}
+		return Theme{}, &ThemeError{Path: path, Err: err}
+	}
+
+	// YAML files can only be kyma themes, parsing them as such reports their
+	// syntax errors. JSON files are either kyma themes or glamour styles.
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" || isKymaTheme(data) {
+		return parseTheme(path, data, opts, append(chain, absPath(path)))
+	}
+
+	glamourTheme, err := parseGlamourTheme(path, data, opts.Strict)
+	if err != nil {
+		return Theme{}, err
+	}
+	return Theme{Name: glamourTheme.Name, Glamour: glamourTheme, Files: []string{path}}, nil
+}
+
+// parseGlamourTheme parses a glamour JSON style. Unknown keys are only
+// rejected in strict mode, glamour itself ignores them.
+func parseGlamourTheme(path string, data []byte, strict bool) (GlamourTheme, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
 
-		return GlamourTheme{Style: customStyle, Name: theme}
+	var style ansi.StyleConfig
+	if err := decoder.Decode(&style); err != nil {
+		return GlamourTheme{}, newJSONThemeError(path, data, err)
 	}
 
-	return GlamourTheme{Style: *style, Name: theme}
+	return GlamourTheme{Style: style, Name: path}, nil
 }
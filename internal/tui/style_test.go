@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSlideSize(t *testing.T) {
+	const width, height = 80, 24
+
+	size := func(t *testing.T, properties string) (int, int) {
+		p, err := NewProperties(properties, ParseOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return lipgloss.Size(p.Style.ApplyStyle(width, height).LipGlossStyle.Render("# Slide"))
+	}
+
+	tt := []struct {
+		name string
+		a, b string
+	}{
+		{"no front matter and transition", "", "transition: flip"},
+		{"no front matter and timer", "", "timer: 20m"},
+		{"no front matter and progress", "", "progress: true"},
+		{"border sides", "style:\n  border: rounded", "style:\n  border: rounded\n  border_sides: top,bottom"},
+		{"margin", "style:\n  border: rounded", "style:\n  border: rounded\n  margin: 1 2"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			aw, ah := size(t, tc.a)
+			bw, bh := size(t, tc.b)
+			if aw != bw || ah != bh {
+				t.Errorf("expected %dx%d, got %dx%d", aw, ah, bw, bh)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/goccy/go-yaml"
+
+	"github.com/museslabs/kyma/internal/paths"
+)
+
+// Theme is a kyma theme, bundling a glamour style with the slide styling that
+// glamour doesn't know about.
+type Theme struct {
+	Name        string
+	Glamour     GlamourTheme
+	Border      string
+	BorderColor string
+	Foreground  string
+	Background  string
+	Header      TextStyle
+	Footer      TextStyle
+	Transition  string
+	// Files are the files the theme was loaded from, empty for built-in
+	// themes.
+	Files []string
+}
+
+type TextStyle struct {
+	Foreground string `yaml:"foreground"`
+	Background string `yaml:"background"`
+	Bold       *bool  `yaml:"bold"`
+	Italic     *bool  `yaml:"italic"`
+}
+
+// ThemeNotFoundError is returned when a theme is neither a built-in theme, a
+// theme in one of the theme directories nor an existing file.
+type ThemeNotFoundError struct {
+	Name string
+	Dirs []string
+}
+
+func (e *ThemeNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"theme not found: %s is not a built-in theme, a theme in %s or a theme file",
+		e.Name,
+		strings.Join(e.Dirs, ", "),
+	)
+}
+
+// ThemeError is returned when a theme file can't be read or parsed. Line and
+// Column are 1-based and zero when the position of the error is unknown.
+type ThemeError struct {
+	Path   string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *ThemeError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("theme %s:%d:%d: %v", e.Path, e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("theme %s: %v", e.Path, e.Err)
+}
+
+func (e *ThemeError) Unwrap() error {
+	return e.Err
+}
+
+func newJSONThemeError(path string, data []byte, err error) error {
+	themeErr := &ThemeError{Path: path, Err: err}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		themeErr.Line, themeErr.Column = position(data, syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		themeErr.Line, themeErr.Column = position(data, typeErr.Offset)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json doesn't report the offset of unknown fields, point to
+		// the first occurrence of the key instead.
+		key := strings.TrimPrefix(err.Error(), "json: unknown field ")
+		themeErr.Err = fmt.Errorf("unknown key %s", key)
+		if i := bytes.Index(data, []byte(key)); i >= 0 {
+			themeErr.Line, themeErr.Column = position(data, int64(i))
+		}
+	}
+
+	return themeErr
+}
+
+func newYAMLThemeError(path string, err error) error {
+	themeErr := &ThemeError{Path: path, Err: err}
+
+	var yamlErr yaml.Error
+	if errors.As(err, &yamlErr) {
+		themeErr.Err = errors.New(yamlErr.GetMessage())
+		if tk := yamlErr.GetToken(); tk != nil && tk.Position != nil {
+			themeErr.Line, themeErr.Column = tk.Position.Line, tk.Position.Column
+		}
+	}
+
+	return themeErr
+}
+
+// position converts a byte offset into a 1-based line and column.
+func position(data []byte, offset int64) (line, column int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+var themeExtensions = []string{".yaml", ".yml", ".json"}
+
+// themeKeys are the top level keys of a kyma theme file, used to tell kyma
+// themes apart from glamour JSON styles which share none of them.
+var themeKeys = []string{
+	"name",
+	"glamour",
+	"border",
+	"border_color",
+	"foreground",
+	"background",
+	"header",
+	"footer",
+	"transition",
+}
+
+// themeDirs returns the directories searched for kyma themes by name, in
+// order of precedence. Local themes are looked up next to the deck.
+func themeDirs(baseDir string) []string {
+	dirs := []string{paths.Resolve(baseDir, "themes")}
+
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return dirs
+		}
+		configDir = filepath.Join(home, ".config")
+	}
+
+	return append(dirs, filepath.Join(configDir, "kyma", "themes"))
+}
+
+// findTheme looks up a kyma theme by name in the theme directories, skipping
+// the theme file exclude so that a theme can reference a glamour style of the
+// same name.
+func findTheme(name, baseDir, exclude string) (string, bool) {
+	if name == "" || strings.ContainsRune(name, filepath.Separator) {
+		return "", false
+	}
+
+	for _, dir := range themeDirs(baseDir) {
+		for _, ext := range themeExtensions {
+			path := filepath.Join(dir, name+ext)
+			if absPath(path) == exclude {
+				continue
+			}
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// absPath returns path made absolute to compare theme files, or path itself
+// when that fails.
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+func isKymaTheme(data []byte) bool {
+	var keys map[string]any
+	if err := yaml.Unmarshal(data, &keys); err != nil {
+		return false
+	}
+
+	for _, key := range themeKeys {
+		if _, ok := keys[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTheme parses a kyma theme file, chain being the theme files loaded so
+// far including this one.
+func parseTheme(path string, data []byte, opts ParseOptions, chain []string) (Theme, error) {
+	aux := struct {
+		Name        string    `yaml:"name"`
+		Glamour     any       `yaml:"glamour"`
+		Border      string    `yaml:"border"`
+		BorderColor string    `yaml:"border_color"`
+		Foreground  string    `yaml:"foreground"`
+		Background  string    `yaml:"background"`
+		Header      TextStyle `yaml:"header"`
+		Footer      TextStyle `yaml:"footer"`
+		Transition  string    `yaml:"transition"`
+	}{}
+
+	if err := yaml.UnmarshalWithOptions(data, &aux, yaml.DisallowUnknownField()); err != nil {
+		return Theme{}, newYAMLThemeError(path, err)
+	}
+
+	for name, color := range map[string]string{
+		"border_color":      aux.BorderColor,
+		"foreground":        aux.Foreground,
+		"background":        aux.Background,
+		"header.foreground": aux.Header.Foreground,
+		"header.background": aux.Header.Background,
+		"footer.foreground": aux.Footer.Foreground,
+		"footer.background": aux.Footer.Background,
+	} {
+		if err := validateColor(name, color); err != nil {
+			return Theme{}, &ThemeError{Path: path, Err: err}
+		}
+	}
+
+	theme := Theme{
+		Name:        aux.Name,
+		Border:      aux.Border,
+		BorderColor: aux.BorderColor,
+		Foreground:  aux.Foreground,
+		Background:  aux.Background,
+		Header:      aux.Header,
+		Footer:      aux.Footer,
+		Transition:  aux.Transition,
+	}
+	if theme.Name == "" {
+		theme.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	var err error
+	switch glamour := aux.Glamour.(type) {
+	case nil:
+		theme.Glamour = GlamourTheme{Style: styles.DarkStyleConfig}
+	case string:
+		var glamourTheme Theme
+		glamourTheme, err = loadTheme(glamour, opts, chain)
+		theme.Glamour = glamourTheme.Glamour
+		theme.Files = glamourTheme.Files
+	case map[string]any:
+		// Inline glamour styles use the same keys as glamour JSON styles, so
+		// round trip them through JSON to reuse its field tags.
+		var b []byte
+		b, err = json.Marshal(glamour)
+		if err != nil {
+			return Theme{}, &ThemeError{Path: path, Err: err}
+		}
+		theme.Glamour, err = parseGlamourTheme(path, b, opts.Strict)
+
+		// Positions in the intermediate JSON don't point anywhere useful.
+		var themeErr *ThemeError
+		if errors.As(err, &themeErr) {
+			themeErr.Line, themeErr.Column = 0, 0
+		}
+	default:
+		err = &ThemeError{Path: path, Err: errors.New("glamour must be a theme name, a path or a style")}
+	}
+	if err != nil {
+		return Theme{}, err
+	}
+	theme.Glamour.Name = theme.Name
+	theme.Files = append([]string{path}, theme.Files...)
+
+	theme.Glamour.Style.H1 = theme.Header.apply(theme.Glamour.Style.H1)
+
+	return theme, nil
+}
+
+func (t TextStyle) apply(style ansi.StyleBlock) ansi.StyleBlock {
+	if t.Foreground != "" {
+		style.Color = &t.Foreground
+	}
+	if t.Background != "" {
+		style.BackgroundColor = &t.Background
+	}
+	if t.Bold != nil {
+		style.Bold = t.Bold
+	}
+	if t.Italic != nil {
+		style.Italic = t.Italic
+	}
+	return style
+}
@@ -0,0 +1,257 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestThemeFileErrors(t *testing.T) {
+	tt := []struct {
+		name     string
+		file     string
+		data     string
+		expected string
+	}{
+		{"yaml syntax", "mine.yaml", "border: [rounded\n", "sequence end token ']' not found"},
+		{"yml syntax", "mine.yml", "border: [rounded\n", "sequence end token ']' not found"},
+		{"json syntax", "mine.json", `{"document": }`, "invalid character '}' looking for beginning of value"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tc.file)
+			if err := os.WriteFile(path, []byte(tc.data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := getTheme(path, ParseOptions{})
+			var themeErr *ThemeError
+			if !errors.As(err, &themeErr) {
+				t.Fatalf("expected a theme error, got %v", err)
+			}
+			if themeErr.Err.Error() != tc.expected || themeErr.Line != 1 {
+				t.Errorf("expected %q on line 1, got %q on line %d", tc.expected, themeErr.Err, themeErr.Line)
+			}
+		})
+	}
+}
+
+func TestStrict(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"glamour.json": `{"document": {"color": "#112233"}, "extra": true}`,
+	})
+
+	tt := []struct {
+		name       string
+		properties string
+		expected   string
+	}{
+		{"unknown front matter key", "transition: flip\nunknown: 1", `unknown field "unknown"`},
+		{"unknown style key", "style:\n  unknown: 1", `unknown field "unknown"`},
+		{"unknown glamour key", "style:\n  theme: glamour.json", "unknown key \"extra\""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewProperties(tc.properties, ParseOptions{BaseDir: dir}); err != nil {
+				t.Errorf("expected no error without strict mode, got %v", err)
+			}
+
+			_, err := NewProperties(tc.properties, ParseOptions{BaseDir: dir, Strict: true})
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected an error containing %q in strict mode, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	const glamourJSON = `{"document": {"color": "#112233"}}`
+
+	tt := []struct {
+		name     string
+		files    map[string]string
+		theme    string
+		border   string
+		color    string
+		expected []string
+	}{
+		{
+			name:     "yaml theme by name",
+			files:    map[string]string{"deck/themes/ocean.yaml": "border: rounded\nglamour: dark\n"},
+			theme:    "ocean",
+			border:   "rounded",
+			expected: []string{"deck/themes/ocean.yaml"},
+		},
+		{
+			name:     "yaml theme by path",
+			files:    map[string]string{"deck/ocean.yml": "border: double\n"},
+			theme:    "ocean.yml",
+			border:   "double",
+			expected: []string{"deck/ocean.yml"},
+		},
+		{
+			name: "local themes before config themes",
+			files: map[string]string{
+				"deck/themes/ocean.yaml":        "border: rounded\n",
+				"config/kyma/themes/ocean.yaml": "border: double\n",
+			},
+			theme:    "ocean",
+			border:   "rounded",
+			expected: []string{"deck/themes/ocean.yaml"},
+		},
+		{
+			name:     "config themes",
+			files:    map[string]string{"config/kyma/themes/ocean.yaml": "border: double\n"},
+			theme:    "ocean",
+			border:   "double",
+			expected: []string{"config/kyma/themes/ocean.yaml"},
+		},
+		{
+			name: "yaml before json",
+			files: map[string]string{
+				"deck/themes/ocean.json": `{"border": "double"}`,
+				"deck/themes/ocean.yaml": "border: rounded\n",
+			},
+			theme:    "ocean",
+			border:   "rounded",
+			expected: []string{"deck/themes/ocean.yaml"},
+		},
+		{
+			name:     "glamour json by name",
+			files:    map[string]string{"deck/themes/ocean.json": glamourJSON},
+			theme:    "ocean",
+			color:    "#112233",
+			expected: []string{"deck/themes/ocean.json"},
+		},
+		{
+			name:     "inline glamour style",
+			files:    map[string]string{"deck/themes/ocean.yaml": "glamour:\n  document:\n    color: \"#112233\"\n"},
+			theme:    "ocean",
+			color:    "#112233",
+			expected: []string{"deck/themes/ocean.yaml"},
+		},
+		{
+			name: "glamour path relative to the theme",
+			files: map[string]string{
+				"deck/themes/ocean.yaml":        "glamour: ./styles/ocean.json\n",
+				"deck/themes/styles/ocean.json": glamourJSON,
+			},
+			theme:    "ocean",
+			color:    "#112233",
+			expected: []string{"deck/themes/ocean.yaml", "deck/themes/styles/ocean.json"},
+		},
+		{
+			name: "glamour named like the theme",
+			files: map[string]string{
+				"deck/themes/ocean.yaml": "border: rounded\nglamour: ocean\n",
+				"deck/themes/ocean.json": glamourJSON,
+			},
+			theme:    "ocean",
+			border:   "rounded",
+			color:    "#112233",
+			expected: []string{"deck/themes/ocean.yaml", "deck/themes/ocean.json"},
+		},
+		{
+			name: "glamour theme by name in the config themes",
+			files: map[string]string{
+				"deck/themes/ocean.yaml":       "glamour: base\n",
+				"config/kyma/themes/base.json": glamourJSON,
+			},
+			theme:    "ocean",
+			color:    "#112233",
+			expected: []string{"deck/themes/ocean.yaml", "config/kyma/themes/base.json"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeFiles(t, tc.files)
+			t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+
+			theme, err := getTheme(tc.theme, ParseOptions{BaseDir: filepath.Join(dir, "deck")})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var expected []string
+			for _, file := range tc.expected {
+				expected = append(expected, filepath.Join(dir, file))
+			}
+			if !reflect.DeepEqual(theme.Files, expected) {
+				t.Errorf("expected files %v, got %v", expected, theme.Files)
+			}
+			if theme.Border != tc.border {
+				t.Errorf("expected border %q, got %q", tc.border, theme.Border)
+			}
+			var color string
+			if c := theme.Glamour.Style.Document.Color; c != nil {
+				color = *c
+			}
+			if tc.color != "" && color != tc.color {
+				t.Errorf("expected document color %q, got %q", tc.color, color)
+			}
+		})
+	}
+}
+
+func TestThemeCycle(t *testing.T) {
+	tt := []struct {
+		name  string
+		files map[string]string
+		theme string
+	}{
+		{"self by path", map[string]string{"themes/self.yaml": "glamour: ./self.yaml\n"}, "self"},
+		{
+			"indirect by name",
+			map[string]string{
+				"themes/a.yaml": "glamour: b\n",
+				"themes/b.yaml": "glamour: a\n",
+			},
+			"a",
+		},
+		{
+			"indirect by path",
+			map[string]string{
+				"themes/a.yaml": "glamour: ./b.json\n",
+				"themes/b.json": `{"glamour": "./a.yaml"}`,
+			},
+			"a",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeFiles(t, tc.files)
+			t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+
+			_, err := getTheme(tc.theme, ParseOptions{BaseDir: dir})
+			var themeErr *ThemeError
+			if !errors.As(err, &themeErr) || !strings.HasPrefix(themeErr.Err.Error(), "glamour references form a cycle: ") {
+				t.Errorf("expected a cycle error, got %v", err)
+			}
+		})
+	}
+}
+
+// writeFiles writes files, keyed by their slash separated path, into a
+// temporary directory and returns it.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
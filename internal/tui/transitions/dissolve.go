@@ -0,0 +1,94 @@
+package transitions
+
+import (
+	"math/rand/v2"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/harmonica"
+)
+
+// dissolveSeed fixes the order in which cells dissolve, so the effect looks
+// the same on every run.
+const dissolveSeed = 0x6b796d61
+
+// dissolve replaces the cells of the previous slide with the cells of the
+// next one in a random order.
+type dissolve struct {
+	fps       int
+	spring    harmonica.Spring
+	progress  float64
+	vel       float64
+	animating bool
+	direction direction
+	// order caches the order in which cells dissolve. It is shared by the
+	// copies of the transition made on every frame, and rebuilt only when
+	// the slides change size.
+	order *[]int
+}
+
+func newDissolve(fps int) dissolve {
+	const frequency = 4.0
+	const damping = 0.8
+
+	return dissolve{
+		fps:    fps,
+		spring: harmonica.NewSpring(harmonica.FPS(fps), frequency, damping),
+	}
+}
+
+func (t dissolve) Start(_, _ int, direction direction) Transition {
+	t.animating = true
+	t.progress = 0
+	t.vel = 0
+	t.direction = direction
+	t.order = new([]int)
+	return t
+}
+
+func (t dissolve) Animating() bool {
+	return t.animating
+}
+
+func (t dissolve) Update() (Transition, tea.Cmd) {
+	t.progress, t.vel = t.spring.Update(t.progress, t.vel, 1)
+
+	if t.progress >= 1 {
+		t.animating = false
+		return t, nil
+	}
+
+	return t, Animate(time.Duration(t.fps))
+}
+
+func (t dissolve) View(prev, next string) string {
+	p, n := frames(prev, next)
+
+	cells := p.Width * p.Height
+	if t.order == nil {
+		t.order = new([]int)
+	}
+	if len(*t.order) != cells {
+		*t.order = rand.New(rand.NewPCG(dissolveSeed, uint64(cells))).Perm(cells)
+	}
+	order := *t.order
+	shown := int(t.progress * float64(cells))
+
+	c := p.Clone()
+	c.DrawFunc(n, 0, 0, func(x, y int) bool {
+		return order[y*p.Width+x] < shown
+	})
+	return c.String()
+}
+
+func (t dissolve) Name() string {
+	return "dissolve"
+}
+
+func (t dissolve) Opposite() Transition {
+	return t
+}
+
+func (t dissolve) Direction() direction {
+	return t.direction
+}
@@ -0,0 +1,41 @@
+package transitions
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDissolve(t *testing.T) {
+	testEndpoints(t, "dissolve")
+	testOpposite(t, map[string]string{"dissolve": "dissolve"})
+}
+
+func TestDissolveFrames(t *testing.T) {
+	prev, next := slide("a", 12, 6), slide("b", 12, 6)
+	halfway := func() dissolve {
+		tr := newDissolve(fps).Start(12, 6, Forwards).(dissolve)
+		tr.progress = 0.5
+		return tr
+	}
+
+	tr := halfway()
+	frame := tr.View(prev, next)
+	if actual := strings.Count(frame, "b"); actual != 36 {
+		t.Errorf("expected half of the 72 cells to be dissolved, got %d", actual)
+	}
+	if actual := halfway().View(prev, next); actual != frame {
+		t.Errorf("expected the same frame on every run, got\n%s\nand\n%s", frame, actual)
+	}
+
+	// Later frames reuse the order of the first one, until the size changes.
+	order := &(*tr.order)[0]
+	updated, _ := tr.Update()
+	updated.View(prev, next)
+	if &(*updated.(dissolve).order)[0] != order {
+		t.Error("expected the dissolve order to be computed once")
+	}
+	updated.View(slide("a", 4, 2), slide("b", 4, 2))
+	if actual := len(*updated.(dissolve).order); actual != 8 {
+		t.Errorf("expected the dissolve order to follow the size of the slides, got %d cells", actual)
+	}
+}
@@ -7,8 +7,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/harmonica"
-	"github.com/muesli/reflow/truncate"
-	"github.com/muesli/reflow/wordwrap"
+	"github.com/charmbracelet/x/ansi"
 )
 
 type flipRight struct {
@@ -71,9 +70,9 @@ func (t flipRight) View(prev string, next string) string {
 	}
 
 	for i := range nextLines {
-		wrappedPrev := strings.Split(wordwrap.String(prevLines[i], x), "\n")
+		wrappedPrev := strings.Split(ansi.Wordwrap(prevLines[i], x, ""), "\n")
 		prev = wrappedPrev[len(wrappedPrev)-1]
-		next := truncate.String(nextLines[i], uint(x))
+		next := ansi.Truncate(nextLines[i], x, "")
 		s.WriteString(next + " " + prev)
 		if i < len(nextLines)-1 {
 			s.WriteString("\n")
@@ -0,0 +1,92 @@
+package transitions
+
+import (
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/harmonica"
+)
+
+// iris uncovers the next slide through a circle growing from the center of
+// the screen, or covers the previous one with a shrinking circle when closing.
+type iris struct {
+	closing   bool
+	fps       int
+	spring    harmonica.Spring
+	progress  float64
+	vel       float64
+	animating bool
+	direction direction
+}
+
+func newIris(closing bool, fps int) iris {
+	const frequency = 6.0
+	const damping = 0.9
+
+	return iris{
+		closing: closing,
+		fps:     fps,
+		spring:  harmonica.NewSpring(harmonica.FPS(fps), frequency, damping),
+	}
+}
+
+func (t iris) Start(_, _ int, direction direction) Transition {
+	t.animating = true
+	t.progress = 0
+	t.vel = 0
+	t.direction = direction
+	return t
+}
+
+func (t iris) Animating() bool {
+	return t.animating
+}
+
+func (t iris) Update() (Transition, tea.Cmd) {
+	t.progress, t.vel = t.spring.Update(t.progress, t.vel, 1)
+
+	if t.progress >= 1 {
+		t.animating = false
+		return t, nil
+	}
+
+	return t, Animate(time.Duration(t.fps))
+}
+
+func (t iris) View(prev, next string) string {
+	p, n := frames(prev, next)
+
+	// Cells are about twice as tall as they are wide, so horizontal distances
+	// count half to keep the circle round.
+	cx, cy := float64(p.Width)/2, float64(p.Height)/2
+	dist := func(x, y float64) float64 {
+		return math.Hypot((x-cx)/2, y-cy)
+	}
+	radius := dist(0, 0) * t.progress
+	if t.closing {
+		radius = dist(0, 0) * (1 - t.progress)
+	}
+
+	c := p.Clone()
+	c.DrawFunc(n, 0, 0, func(x, y int) bool {
+		inside := dist(float64(x)+0.5, float64(y)+0.5) < radius
+		return inside != t.closing
+	})
+	return c.String()
+}
+
+func (t iris) Name() string {
+	if t.closing {
+		return "irisClose"
+	}
+	return "iris"
+}
+
+func (t iris) Opposite() Transition {
+	return newIris(!t.closing, t.fps)
+}
+
+func (t iris) Direction() direction {
+	return t.direction
+}
@@ -0,0 +1,44 @@
+package transitions
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIris(t *testing.T) {
+	testEndpoints(t, "iris", "irisClose")
+	testOpposite(t, map[string]string{
+		"iris":      "irisClose",
+		"irisClose": "iris",
+	})
+}
+
+func TestIrisMidpoint(t *testing.T) {
+	// Halfway the circle is half as wide as the screen, and twice as wide as
+	// it is tall since cells are tall.
+	opening := []string{
+		"aaaaaaaaaaaa",
+		"aaabbbbbbaaa",
+		"aabbbbbbbbaa",
+		"aabbbbbbbbaa",
+		"aaabbbbbbaaa",
+		"aaaaaaaaaaaa",
+	}
+	closing := strings.NewReplacer("a", "b", "b", "a")
+
+	tt := []struct {
+		closing  bool
+		expected string
+	}{
+		{false, strings.Join(opening, "\n")},
+		{true, closing.Replace(strings.Join(opening, "\n"))},
+	}
+
+	for _, tc := range tt {
+		tr := newIris(tc.closing, fps)
+		tr.progress = 0.5
+		if actual := tr.View(slide("a", 12, 6), slide("b", 12, 6)); actual != tc.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", tr.Name(), tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package transitions
+
+import (
+	"math/rand/v2"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/museslabs/kyma/internal/canvas"
+)
+
+const (
+	// matrixDuration is how long the rain takes to reveal the next slide.
+	matrixDuration = 1500 * time.Millisecond
+	// matrixTrail is the length of the trail behind every drop.
+	matrixTrail = 8
+	// matrixSeed fixes the delay of every column and the falling glyphs.
+	matrixSeed = 0x6b796d61
+)
+
+// matrixGlyphs are the characters drops are made of, all a single cell wide.
+var matrixGlyphs = []rune("ｱｲｳｴｵｶｷｸｹｺｻｼｽｾｿﾀﾁﾂﾃﾄﾅﾆﾇﾈﾉﾊﾋﾌﾍﾎﾏﾐﾑﾒﾓﾔﾕﾖﾗﾘﾙﾚﾛﾜﾝ0123456789")
+
+var (
+	matrixHead = canvas.RGB(220, 255, 220)
+	matrixLit  = canvas.RGB(0, 255, 70)
+	matrixDim  = canvas.RGB(0, 60, 15)
+)
+
+// matrix drops green glyphs down every column of the previous slide, leaving
+// the next slide behind them.
+type matrix struct {
+	fps       int
+	frame     int
+	animating bool
+	direction direction
+}
+
+func newMatrix(fps int) matrix {
+	return matrix{fps: fps}
+}
+
+func (t matrix) Start(_, _ int, direction direction) Transition {
+	t.animating = true
+	t.frame = 0
+	t.direction = direction
+	return t
+}
+
+func (t matrix) Animating() bool {
+	return t.animating
+}
+
+// length is the number of frames the rain lasts.
+func (t matrix) length() int {
+	return max(1, int(matrixDuration.Seconds()*float64(t.fps)))
+}
+
+func (t matrix) Update() (Transition, tea.Cmd) {
+	t.frame++
+
+	if t.frame >= t.length() {
+		t.animating = false
+		return t, nil
+	}
+
+	return t, Animate(time.Duration(t.fps))
+}
+
+func (t matrix) View(prev, next string) string {
+	p, n := frames(prev, next)
+	progress := float64(t.frame) / float64(t.length())
+
+	// Columns start falling at random times during the first half, and all
+	// drops leave the screen with their trail by the end.
+	delays := rand.New(rand.NewPCG(matrixSeed, uint64(p.Width)))
+	glyphs := rand.New(rand.NewPCG(matrixSeed, uint64(t.frame)))
+	c := p.Clone()
+	for x := 0; x < p.Width; x++ {
+		delay := delays.Float64() / 2
+		fall := max(0, progress-delay) / (1 - delay)
+		if fall == 0 {
+			// The drop waits above the screen.
+			continue
+		}
+		head := int(fall * float64(p.Height+matrixTrail))
+
+		for y := 0; y < min(head+1, p.Height); y++ {
+			behind := head - y
+			if behind >= matrixTrail {
+				c.Set(x, y, n.Cell(x, y))
+				continue
+			}
+
+			style := canvas.Style{
+				Fg: canvas.Blend(matrixLit, matrixDim, float64(behind)/matrixTrail),
+				Bg: p.Cell(x, y).Style.Bg,
+			}
+			if behind == 0 {
+				style.Fg = matrixHead
+				style.Attrs = canvas.Bold
+			}
+			glyph := matrixGlyphs[glyphs.IntN(len(matrixGlyphs))]
+			c.Set(x, y, canvas.Cell{Content: string(glyph), Width: 1, Style: style})
+		}
+	}
+	return c.String()
+}
+
+func (t matrix) Name() string {
+	return "matrix"
+}
+
+func (t matrix) Opposite() Transition {
+	return t
+}
+
+func (t matrix) Direction() direction {
+	return t.direction
+}
@@ -0,0 +1,28 @@
+package transitions
+
+import "testing"
+
+func TestMatrix(t *testing.T) {
+	testEndpoints(t, "matrix")
+	testOpposite(t, map[string]string{"matrix": "matrix"})
+}
+
+func TestMatrixFrames(t *testing.T) {
+	prev, next := slide("a", 12, 6), slide("b", 12, 6)
+	at := func(frame int) string {
+		tr := newMatrix(fps).Start(12, 6, Forwards).(matrix)
+		tr.frame = frame
+		return tr.View(prev, next)
+	}
+
+	frame := at(fps / 2)
+	if frame == prev || frame == next {
+		t.Errorf("expected drops halfway through, got\n%s", frame)
+	}
+	if actual := at(fps / 2); actual != frame {
+		t.Errorf("expected the same frame on every run, got\n%s\nand\n%s", frame, actual)
+	}
+	if actual := at(fps/2 + 1); actual == frame {
+		t.Errorf("expected the drops to move between frames, got\n%s", actual)
+	}
+}
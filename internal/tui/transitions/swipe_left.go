@@ -2,14 +2,12 @@ package transitions
 
 import (
 	"math"
-	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/harmonica"
-	"github.com/muesli/reflow/truncate"
 
-	"github.com/museslabs/kyma/internal/skip"
+	"github.com/museslabs/kyma/internal/canvas"
 )
 
 type swipeLeft struct {
@@ -59,27 +57,15 @@ func (t swipeLeft) Update() (Transition, tea.Cmd) {
 }
 
 func (t swipeLeft) View(prev string, next string) string {
-	var s strings.Builder
+	p, n := frames(prev, next)
 
-	x := int(math.Round(t.x))
+	// The spring moves over the terminal width, the slide may be narrower.
+	x := int(math.Round(t.x * float64(p.Width) / float64(max(t.width, 1))))
 
-	prevLines := strings.Split(prev, "\n")
-	nextLines := strings.Split(next, "\n")
-
-	// assert that slides are always equal height
-	if len(nextLines) != len(prevLines) {
-		panic("Slides of not equal height")
-	}
-
-	for i := range nextLines {
-		prev := skip.String(prevLines[i], uint(x))
-		next := truncate.String(nextLines[i], uint(x))
-		s.WriteString(prev + " " + next)
-		if i < len(nextLines)-1 {
-			s.WriteString("\n")
-		}
-	}
-	return s.String()
+	c := canvas.New(p.Width, p.Height)
+	c.Draw(p, -x, 0)
+	c.Draw(n, p.Width-x+1, 0)
+	return c.String()
 }
 
 func (t swipeLeft) Name() string {
@@ -2,14 +2,12 @@ package transitions
 
 import (
 	"math"
-	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/harmonica"
-	"github.com/muesli/reflow/truncate"
 
-	"github.com/museslabs/kyma/internal/skip"
+	"github.com/museslabs/kyma/internal/canvas"
 )
 
 type swipeRight struct {
@@ -59,27 +57,15 @@ func (t swipeRight) Update() (Transition, tea.Cmd) {
 }
 
 func (t swipeRight) View(prev string, next string) string {
-	var s strings.Builder
+	p, n := frames(prev, next)
 
-	x := int(math.Round(t.x))
+	// The spring moves over the terminal width, the slide may be narrower.
+	x := int(math.Round(t.x * float64(p.Width) / float64(max(t.width, 1))))
 
-	prevLines := strings.Split(prev, "\n")
-	nextLines := strings.Split(next, "\n")
-
-	// assert that slides are always equal height
-	if len(nextLines) != len(prevLines) {
-		panic("Slides of not equal height")
-	}
-
-	for i := range nextLines {
-		prev := truncate.String(prevLines[i], uint(t.width+x))
-		next := skip.String(nextLines[i], uint(t.width+x))
-		s.WriteString(next + " " + prev)
-		if i < len(nextLines)-1 {
-			s.WriteString("\n")
-		}
-	}
-	return s.String()
+	c := canvas.New(p.Width, p.Height)
+	c.Draw(n, -p.Width-x, 0)
+	c.Draw(p, 1-x, 0)
+	return c.String()
 }
 
 func (t swipeRight) Name() string {
@@ -4,6 +4,9 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/museslabs/kyma/internal/canvas"
 )
 
 type direction byte
@@ -31,6 +34,14 @@ type Transition interface {
 	Direction() direction
 }
 
+// frames parses both slides on canvases of the same size, the size of the
+// larger one.
+func frames(prev, next string) (*canvas.Canvas, *canvas.Canvas) {
+	width := max(lipgloss.Width(prev), lipgloss.Width(next))
+	height := max(lipgloss.Height(prev), lipgloss.Height(next))
+	return canvas.Parse(prev, width, height), canvas.Parse(next, width, height)
+}
+
 func Get(name string, fps int) Transition {
 	switch name {
 	case "slideUp":
@@ -43,6 +54,22 @@ func Get(name string, fps int) Transition {
 		return newSwipeRight(fps)
 	case "flip":
 		return newFlipRight(fps)
+	case "wipeLeft":
+		return newWipe(left, fps)
+	case "wipeRight":
+		return newWipe(right, fps)
+	case "wipeUp":
+		return newWipe(up, fps)
+	case "wipeDown":
+		return newWipe(down, fps)
+	case "iris":
+		return newIris(false, fps)
+	case "irisClose":
+		return newIris(true, fps)
+	case "dissolve":
+		return newDissolve(fps)
+	case "matrix":
+		return newMatrix(fps)
 	default:
 		return newNoTransition(fps)
 	}
@@ -0,0 +1,92 @@
+package transitions
+
+import (
+	"strings"
+	"testing"
+)
+
+const fps = 60
+
+// slide returns a rendered slide of the given size filled with c.
+func slide(c string, width, height int) string {
+	return strings.TrimSuffix(strings.Repeat(strings.Repeat(c, width)+"\n", height), "\n")
+}
+
+// finish updates t until its animation ends.
+func finish(t *testing.T, tr Transition) Transition {
+	t.Helper()
+
+	for i := 0; tr.Animating(); i++ {
+		if i > 10*fps {
+			t.Fatalf("%s: still animating after 10 seconds", tr.Name())
+		}
+		tr, _ = tr.Update()
+	}
+	return tr
+}
+
+func TestGet(t *testing.T) {
+	tt := []struct {
+		name     string
+		expected string
+	}{
+		{"slideUp", "slideUp"},
+		{"slideDown", "slideDown"},
+		{"swipeLeft", "swipeLeft"},
+		{"swipeRight", "swipeRight"},
+		{"flip", "flipRight"},
+		{"wipeLeft", "wipeLeft"},
+		{"wipeRight", "wipeRight"},
+		{"wipeUp", "wipeUp"},
+		{"wipeDown", "wipeDown"},
+		{"iris", "iris"},
+		{"irisClose", "irisClose"},
+		{"dissolve", "dissolve"},
+		{"matrix", "matrix"},
+		{"unknown", "none"},
+	}
+
+	for _, tc := range tt {
+		if actual := Get(tc.name, fps).Name(); actual != tc.expected {
+			t.Errorf("Get(%q): expected a transition named %q, got %q", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+// testOpposite checks that the opposite of every named transition is
+// expected and that going there and back returns the same transition.
+func testOpposite(t *testing.T, opposites map[string]string) {
+	t.Helper()
+
+	for name, expected := range opposites {
+		tr := Get(name, fps)
+		if actual := tr.Opposite().Name(); actual != expected {
+			t.Errorf("%s: expected the opposite %q, got %q", name, expected, actual)
+		}
+		if actual := tr.Opposite().Opposite().Name(); actual != name {
+			t.Errorf("%s: expected the opposite of the opposite to be itself, got %q", name, actual)
+		}
+		for _, d := range []direction{Forwards, Backwards} {
+			if actual := tr.Start(10, 5, d).Direction(); actual != d {
+				t.Errorf("%s: expected direction %d, got %d", name, d, actual)
+			}
+		}
+	}
+}
+
+// testEndpoints checks that every named transition shows prev when it starts
+// and next when it ends.
+func testEndpoints(t *testing.T, names ...string) {
+	t.Helper()
+
+	prev, next := slide("a", 12, 6), slide("b", 12, 6)
+	for _, name := range names {
+		tr := Get(name, fps).Start(12, 6, Forwards)
+		if actual := tr.View(prev, next); actual != prev {
+			t.Errorf("%s: expected the previous slide at the start, got\n%s", name, actual)
+		}
+		if actual := finish(t, tr).View(prev, next); actual != next {
+			t.Errorf("%s: expected the next slide at the end, got\n%s", name, actual)
+		}
+	}
+}
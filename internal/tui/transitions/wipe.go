@@ -0,0 +1,106 @@
+package transitions
+
+import (
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/harmonica"
+)
+
+// side is the edge a wipe moves towards.
+type side byte
+
+const (
+	left side = iota
+	right
+	up
+	down
+)
+
+// wipe uncovers the next slide in place, its edge moving across the previous
+// one towards a side of the screen.
+type wipe struct {
+	side      side
+	fps       int
+	spring    harmonica.Spring
+	progress  float64
+	vel       float64
+	animating bool
+	direction direction
+}
+
+func newWipe(side side, fps int) wipe {
+	const frequency = 7.0
+	const damping = 0.8
+
+	return wipe{
+		side:   side,
+		fps:    fps,
+		spring: harmonica.NewSpring(harmonica.FPS(fps), frequency, damping),
+	}
+}
+
+func (t wipe) Start(_, _ int, direction direction) Transition {
+	t.animating = true
+	t.progress = 0
+	t.vel = 0
+	t.direction = direction
+	return t
+}
+
+func (t wipe) Animating() bool {
+	return t.animating
+}
+
+func (t wipe) Update() (Transition, tea.Cmd) {
+	t.progress, t.vel = t.spring.Update(t.progress, t.vel, 1)
+
+	if t.progress >= 1 {
+		t.animating = false
+		return t, nil
+	}
+
+	return t, Animate(time.Duration(t.fps))
+}
+
+func (t wipe) View(prev, next string) string {
+	p, n := frames(prev, next)
+	w := int(math.Round(t.progress * float64(p.Width)))
+	h := int(math.Round(t.progress * float64(p.Height)))
+
+	c := p.Clone()
+	c.DrawFunc(n, 0, 0, func(x, y int) bool {
+		switch t.side {
+		case left:
+			return x >= p.Width-w
+		case right:
+			return x < w
+		case up:
+			return y >= p.Height-h
+		}
+		return y < h
+	})
+	return c.String()
+}
+
+func (t wipe) Name() string {
+	switch t.side {
+	case left:
+		return "wipeLeft"
+	case right:
+		return "wipeRight"
+	case up:
+		return "wipeUp"
+	}
+	return "wipeDown"
+}
+
+func (t wipe) Opposite() Transition {
+	opposite := map[side]side{left: right, right: left, up: down, down: up}
+	return newWipe(opposite[t.side], t.fps)
+}
+
+func (t wipe) Direction() direction {
+	return t.direction
+}
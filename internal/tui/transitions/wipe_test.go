@@ -0,0 +1,37 @@
+package transitions
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWipe(t *testing.T) {
+	testEndpoints(t, "wipeLeft", "wipeRight", "wipeUp", "wipeDown")
+	testOpposite(t, map[string]string{
+		"wipeLeft":  "wipeRight",
+		"wipeRight": "wipeLeft",
+		"wipeUp":    "wipeDown",
+		"wipeDown":  "wipeUp",
+	})
+}
+
+func TestWipeMidpoint(t *testing.T) {
+	tt := []struct {
+		side     side
+		expected []string
+	}{
+		{left, []string{"aabb", "aabb"}},
+		{right, []string{"bbaa", "bbaa"}},
+		{up, []string{"aaaa", "bbbb"}},
+		{down, []string{"bbbb", "aaaa"}},
+	}
+
+	for _, tc := range tt {
+		tr := newWipe(tc.side, fps)
+		tr.progress = 0.5
+		actual := tr.View(slide("a", 4, 2), slide("b", 4, 2))
+		if expected := strings.Join(tc.expected, "\n"); actual != expected {
+			t.Errorf("%s: expected %q, got %q", tr.Name(), expected, actual)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"time"
+
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -10,17 +12,24 @@ import (
 )
 
 type keyMap struct {
-	Quit key.Binding
-	Next key.Binding
-	Prev key.Binding
+	Quit     key.Binding
+	Next     key.Binding
+	Prev     key.Binding
+	Progress key.Binding
+	Timer    key.Binding
+	Dismiss  key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Quit}
+	return []key.Binding{k.Quit, k.Progress, k.Timer, k.Dismiss}
 }
 
 func (k keyMap) FullHelp() [][]key.Binding {
-	return nil
+	return [][]key.Binding{
+		{k.Next, k.Prev},
+		{k.Progress, k.Timer, k.Dismiss},
+		{k.Quit},
+	}
 }
 
 var keys = keyMap{
@@ -36,6 +45,18 @@ var keys = keyMap{
 		key.WithKeys("left", "h"),
 		key.WithHelp("<, h", "previous"),
 	),
+	Progress: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "toggle progress bar"),
+	),
+	Timer: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "toggle timer"),
+	),
+	Dismiss: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "dismiss error"),
+	),
 }
 
 const Fps = 60
@@ -51,70 +72,186 @@ type model struct {
 	slide *Slide
 	keys  keyMap
 	help  help.Model
+
+	showProgress bool
+	showTimer    bool
+	timerTarget  time.Duration
+	start        time.Time
+	ticking      bool
+
+	reloadErr   error
+	followEdits bool
 }
 
-func New(rootSlide *Slide) model {
-	return model{
-		slide: rootSlide,
-		keys:  keys,
-		help:  help.New(),
+// Option configures the model returned by New.
+type Option func(*model)
+
+// WithFollowEdits makes reloads navigate to the first slide that changed.
+func WithFollowEdits() Option {
+	return func(m *model) {
+		m.followEdits = true
+	}
+}
+
+func New(rootSlide *Slide, opts ...Option) model {
+	identify(rootSlide)
+
+	m := model{
+		slide:        rootSlide,
+		keys:         keys,
+		help:         help.New(),
+		showProgress: rootSlide.Properties.Progress,
+		showTimer:    rootSlide.Properties.Timer > 0,
+		timerTarget:  rootSlide.Properties.Timer,
+		start:        time.Now(),
+	}
+	for _, opt := range opts {
+		opt(&m)
 	}
+	m.ticking = m.showTimer
+
+	return m
 }
 
 func (m model) Init() tea.Cmd {
+	if m.ticking {
+		return tea.Batch(tea.ClearScreen, tickTimer())
+	}
 	return tea.ClearScreen
 }
 
+// startTimer starts ticking when the timer is shown and is not ticking yet.
+// Elapsed time is measured from the start, so a hidden timer doesn't need to
+// tick.
+func (m *model) startTimer() tea.Cmd {
+	if m.ticking || !m.showTimer {
+		return nil
+	}
+	m.ticking = true
+	return tickTimer()
+}
+
+// slideHeight returns the height available to slides, leaving room for the
+// footer when it is visible.
+func (m model) slideHeight() int {
+	if m.footerVisible() {
+		return m.height - 1
+	}
+	return m.height
+}
+
+func (m model) root() *Slide {
+	root := m.slide
+	for root.Prev != nil {
+		root = root.Prev
+	}
+	return root
+}
+
+func (m model) restyle() {
+	for slide := m.root(); slide != nil; slide = slide.Next {
+		slide.Style = style(m.width, m.slideHeight(), slide.Properties.Style)
+	}
+}
+
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case ReloadErrorMsg:
+		m.reloadErr = msg.Err
+		return m, nil
 	case UpdateSlidesMsg:
-		// Find current position in the slide list
-		currentPosition := 0
-		for currentSlide := m.slide; currentSlide != nil && currentSlide.Prev != nil; currentSlide = currentSlide.Prev {
-			currentPosition++
+		m.reloadErr = nil
+
+		identify(msg.NewRoot)
+
+		var changed *Slide
+		if m.followEdits {
+			changed = firstChanged(m.root(), msg.NewRoot)
 		}
+		current, _ := m.slide.position()
 
-		// Update root and navigate to the same position in the new list
-		m.slide = msg.NewRoot
-		for i := 0; i < currentPosition && m.slide != nil; i++ {
-			m.slide = m.slide.Next
+		// Stay on the same logical slide, falling back to the same position
+		// when it is gone.
+		if slide := find(msg.NewRoot, m.slide.id); slide != nil {
+			slide.step = min(m.slide.step, slide.steps()-1)
+			m.slide = slide
+		} else {
+			m.slide = msg.NewRoot
+			for i := 1; i < current && m.slide.Next != nil; i++ {
+				m.slide = m.slide.Next
+			}
 		}
 
+		m.timerTarget = msg.NewRoot.Properties.Timer
+
 		// Reset state for all slides in the new list
-		for currentSlide := m.slide; currentSlide != nil; currentSlide = currentSlide.Next {
+		for currentSlide := msg.NewRoot; currentSlide != nil; currentSlide = currentSlide.Next {
 			currentSlide.ActiveTransition = nil
-			currentSlide.Style = style(m.width, m.height, currentSlide.Properties.Style)
+		}
+		m.restyle()
+
+		if changed != nil && changed != m.slide {
+			return m, m.goTo(changed)
 		}
 		return m, nil
 	case tea.WindowSizeMsg:
 		m.width, m.height = msg.Width, msg.Height
-		slide := m.slide
-		for slide != nil {
-			slide.Style = style(m.width, m.height, slide.Properties.Style)
-			slide = slide.Next
-		}
+		m.restyle()
 		return m, nil
+	case timerTickMsg:
+		if !m.showTimer {
+			m.ticking = false
+			return m, nil
+		}
+		return m, tickTimer()
 	case tea.KeyMsg:
 		if key.Matches(msg, m.keys.Quit) {
 			return m, tea.Quit
+		} else if m.reloadErr != nil && key.Matches(msg, m.keys.Dismiss) {
+			m.reloadErr = nil
+			return m, nil
+		} else if key.Matches(msg, m.keys.Progress) {
+			m.showProgress = !m.showProgress
+			m.restyle()
+			return m, nil
+		} else if key.Matches(msg, m.keys.Timer) {
+			m.showTimer = !m.showTimer
+			m.restyle()
+			return m, m.startTimer()
 		} else if key.Matches(msg, m.keys.Next) {
-			if m.slide.Next == nil || m.slide.ActiveTransition != nil && m.slide.ActiveTransition.Animating() {
+			if m.slide.ActiveTransition != nil && m.slide.ActiveTransition.Animating() {
+				return m, nil
+			}
+			if m.slide.step < m.slide.steps()-1 {
+				m.slide.step++
+				return m, nil
+			}
+			if m.slide.Next == nil {
 				return m, nil
 			}
 			m.slide = m.slide.Next
-			m.slide.ActiveTransition = m.slide.Properties.Transition.Start(m.width, m.height, transitions.Forwards)
+			m.slide.step = 0
+			m.slide.ActiveTransition = m.slide.Properties.Transition.Start(m.width, m.slideHeight(), transitions.Forwards)
 			return m, transitions.Animate(Fps)
 		} else if key.Matches(msg, m.keys.Prev) {
-			if m.slide.Prev == nil || m.slide.ActiveTransition != nil && m.slide.ActiveTransition.Animating() {
+			if m.slide.ActiveTransition != nil && m.slide.ActiveTransition.Animating() {
+				return m, nil
+			}
+			if m.slide.step > 0 {
+				m.slide.step--
+				return m, nil
+			}
+			if m.slide.Prev == nil {
 				return m, nil
 			}
 			m.slide = m.slide.Prev
+			m.slide.step = m.slide.steps() - 1
 			m.slide.ActiveTransition = m.slide.
 				Next.
 				Properties.
 				Transition.
 				Opposite().
-				Start(m.width, m.height, transitions.Backwards)
+				Start(m.width, m.slideHeight(), transitions.Backwards)
 
 			return m, transitions.Animate(Fps)
 		}
@@ -127,14 +264,43 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// goTo navigates to the target slide, playing the transition that would lead
+// to it from its neighbour in the direction of travel.
+func (m *model) goTo(target *Slide) tea.Cmd {
+	from, _ := m.slide.position()
+	to, _ := target.position()
+	m.slide = target
+
+	if to > from && target.Prev != nil {
+		target.ActiveTransition = target.Properties.Transition.Start(m.width, m.slideHeight(), transitions.Forwards)
+		return transitions.Animate(Fps)
+	}
+	if to < from && target.Next != nil {
+		target.ActiveTransition = target.Next.Properties.Transition.Opposite().
+			Start(m.width, m.slideHeight(), transitions.Backwards)
+		return transitions.Animate(Fps)
+	}
+	return nil
+}
+
 func (m model) View() string {
-	m.slide.Style = style(m.width, m.height, m.slide.Properties.Style)
+	m.slide.Style = style(m.width, m.slideHeight(), m.slide.Properties.Style)
 
-	return lipgloss.Place(
+	slide := lipgloss.Place(
 		m.width,
-		m.height,
+		m.slideHeight(),
 		lipgloss.Center,
 		lipgloss.Center,
 		m.slide.View(),
 	)
+
+	if m.reloadErr != nil {
+		banner := errorBanner(m.reloadErr, m.width)
+		slide = overlay(slide, banner, (m.width-lipgloss.Width(banner))/2, 1)
+	}
+
+	if !m.footerVisible() {
+		return slide
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, slide, m.footer())
 }